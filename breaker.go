@@ -0,0 +1,164 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultBreakerFailures is the number of consecutive failures that open
+	// a circuit breaker when CircuitBreakerOptions.Failures is not set.
+	DefaultBreakerFailures = 3
+
+	// DefaultBreakerCooldown is how long a circuit breaker stays open when
+	// CircuitBreakerOptions.Cooldown is not set.
+	DefaultBreakerCooldown = 30 * time.Second
+)
+
+// Circuit breaker states, as recorded in CheckResult.Metadata under
+// "circuitBreaker".
+const (
+	BreakerClosed   = "closed"
+	BreakerOpen     = "open"
+	BreakerHalfOpen = "half-open"
+)
+
+// CircuitBreakerOptions configure a CircuitBreaker.
+type CircuitBreakerOptions struct {
+	// Failures is the number of consecutive unhealthy results that open the
+	// breaker. Default: DefaultBreakerFailures.
+	Failures int
+	// Cooldown is how long the breaker stays open before letting a single
+	// trial check through. Default: DefaultBreakerCooldown.
+	Cooldown time.Duration
+}
+
+// CircuitBreaker wraps a Checker so that a dependency known to be down is
+// not checked over and over. After a number of consecutive failures the
+// breaker opens, and the last unhealthy result is returned without calling
+// the wrapped checker. Once the cooldown has passed, the breaker half-opens
+// and lets one check through: if it passes the breaker closes, and if it
+// fails the breaker opens for another cooldown.
+//
+// Every result records the breaker state in Metadata under
+// "circuitBreaker", the consecutive failure count under "breakerFailures",
+// and, while open, when the next trial is due under "breakerRetryAt".
+type CircuitBreaker struct {
+	inner    Checker
+	failures int
+	cooldown time.Duration
+
+	mu          sync.Mutex
+	state       string
+	consecutive int
+	openedAt    time.Time
+	trial       bool
+	last        *CheckResult
+}
+
+// WithCircuitBreaker wraps a Checker with a circuit breaker.
+func WithCircuitBreaker(c Checker, opts CircuitBreakerOptions) *CircuitBreaker {
+	b := &CircuitBreaker{
+		inner:    c,
+		failures: opts.Failures,
+		cooldown: opts.Cooldown,
+		state:    BreakerClosed,
+	}
+	if b.failures <= 0 {
+		b.failures = DefaultBreakerFailures
+	}
+	if b.cooldown <= 0 {
+		b.cooldown = DefaultBreakerCooldown
+	}
+	return b
+}
+
+// Check calls the wrapped checker unless the breaker is open, in which case
+// it returns the last unhealthy result.
+func (b *CircuitBreaker) Check(ctx context.Context) *CheckResult {
+	b.mu.Lock()
+	if b.state == BreakerOpen && time.Since(b.openedAt) >= b.cooldown {
+		b.state = BreakerHalfOpen
+	}
+	// while half-open, only one trial check runs at a time
+	if b.state == BreakerOpen || b.trial {
+		defer b.mu.Unlock()
+		return b.shortCircuit()
+	}
+	b.trial = b.state == BreakerHalfOpen
+	b.mu.Unlock()
+
+	r := b.check(ctx)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.trial = false
+
+	if r == nil {
+		now := time.Now()
+		r = &CheckResult{Status: StatusUnhealthy, Error: errors.New("no result"), ErrorSince: now, Timestamp: now}
+	}
+	if r.Status != StatusUnhealthy {
+		b.state = BreakerClosed
+		b.consecutive = 0
+		return b.annotate(r)
+	}
+
+	b.consecutive++
+	b.last = r
+	if b.state == BreakerHalfOpen || b.consecutive >= b.failures {
+		b.state = BreakerOpen
+		b.openedAt = time.Now()
+	}
+	return b.annotate(r)
+}
+
+// check calls the wrapped checker. A panic is returned as an unhealthy
+// result, so that a panicking trial reopens the breaker instead of leaving
+// it half-open for good.
+func (b *CircuitBreaker) check(ctx context.Context) (r *CheckResult) {
+	defer func() {
+		if p := recover(); p != nil {
+			now := time.Now()
+			r = &CheckResult{
+				Status:     StatusUnhealthy,
+				Error:      fmt.Errorf("checker panicked: %v", p),
+				ErrorSince: now,
+				Timestamp:  now,
+			}
+		}
+	}()
+	return b.inner.Check(ctx)
+}
+
+// shortCircuit returns the last unhealthy result in place of a check. The
+// caller must hold mu.
+func (b *CircuitBreaker) shortCircuit() *CheckResult {
+	out := *b.last
+	out.Timestamp = time.Now()
+	out.Duration = 0
+	return b.annotate(&out)
+}
+
+// annotate returns a copy of r with the breaker state in its Metadata. The
+// caller must hold mu.
+func (b *CircuitBreaker) annotate(r *CheckResult) *CheckResult {
+	out := *r
+	out.Metadata = maps.Clone(r.Metadata)
+	if out.Metadata == nil {
+		out.Metadata = make(map[string]string, 3)
+	}
+	out.Metadata["circuitBreaker"] = b.state
+	out.Metadata["breakerFailures"] = strconv.Itoa(b.consecutive)
+	if b.state == BreakerOpen {
+		out.Metadata["breakerRetryAt"] = b.openedAt.Add(b.cooldown).Format(time.RFC3339)
+	} else {
+		delete(out.Metadata, "breakerRetryAt")
+	}
+	return &out
+}
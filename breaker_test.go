@@ -0,0 +1,138 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/schigh/health/v2"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	var (
+		calls   atomic.Int32
+		healthy atomic.Bool
+	)
+	inner := health.CheckerFunc(func(context.Context) *health.CheckResult {
+		calls.Add(1)
+		if healthy.Load() {
+			return &health.CheckResult{Status: health.StatusHealthy}
+		}
+		return &health.CheckResult{Status: health.StatusUnhealthy, Error: errors.New("connection refused")}
+	})
+
+	b := health.WithCircuitBreaker(inner, health.CircuitBreakerOptions{Failures: 2, Cooldown: 50 * time.Millisecond})
+	ctx := context.Background()
+
+	r := b.Check(ctx)
+	if r.Metadata["circuitBreaker"] != health.BreakerClosed || r.Metadata["breakerFailures"] != "1" {
+		t.Fatalf("expected closed breaker after one failure, got %v", r.Metadata)
+	}
+	r = b.Check(ctx)
+	if r.Metadata["circuitBreaker"] != health.BreakerOpen || r.Metadata["breakerRetryAt"] == "" {
+		t.Fatalf("expected breaker to open after two failures, got %v", r.Metadata)
+	}
+
+	// while open, the inner checker is not called
+	r = b.Check(ctx)
+	if calls.Load() != 2 {
+		t.Fatalf("expected open breaker to skip the inner checker, got %d calls", calls.Load())
+	}
+	if r.Status != health.StatusUnhealthy || r.Error == nil || r.Error.Error() != "connection refused" {
+		t.Fatalf("expected last unhealthy result while open, got %+v", r)
+	}
+
+	// a failed trial reopens the breaker
+	time.Sleep(60 * time.Millisecond)
+	r = b.Check(ctx)
+	if calls.Load() != 3 || r.Metadata["circuitBreaker"] != health.BreakerOpen {
+		t.Fatalf("expected failed trial to reopen the breaker, got %d calls and %v", calls.Load(), r.Metadata)
+	}
+
+	// a passing trial closes it
+	healthy.Store(true)
+	time.Sleep(60 * time.Millisecond)
+	r = b.Check(ctx)
+	if r.Status != health.StatusHealthy || r.Metadata["circuitBreaker"] != health.BreakerClosed || r.Metadata["breakerFailures"] != "0" {
+		t.Fatalf("expected passing trial to close the breaker, got %+v", r)
+	}
+}
+
+func TestCircuitBreaker_SingleTrial(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	inner := health.CheckerFunc(func(context.Context) *health.CheckResult {
+		if calls.Add(1) > 1 {
+			<-release
+		}
+		return &health.CheckResult{Status: health.StatusUnhealthy}
+	})
+
+	b := health.WithCircuitBreaker(inner, health.CircuitBreakerOptions{Failures: 1, Cooldown: 10 * time.Millisecond})
+	ctx := context.Background()
+	_ = b.Check(ctx)
+	time.Sleep(20 * time.Millisecond)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = b.Check(ctx)
+	}()
+	for calls.Load() < 2 {
+		time.Sleep(time.Millisecond)
+	}
+
+	r := b.Check(ctx)
+	if calls.Load() != 2 || r.Metadata["circuitBreaker"] != health.BreakerHalfOpen {
+		t.Fatalf("expected concurrent check during a trial to short-circuit, got %d calls and %v", calls.Load(), r.Metadata)
+	}
+	close(release)
+	<-done
+}
+
+func TestCircuitBreaker_PanickingTrial(t *testing.T) {
+	const (
+		failing = iota
+		panicking
+		passing
+	)
+	var (
+		calls atomic.Int32
+		mode  atomic.Int32
+	)
+	inner := health.CheckerFunc(func(context.Context) *health.CheckResult {
+		calls.Add(1)
+		switch mode.Load() {
+		case panicking:
+			panic("boom")
+		case passing:
+			return &health.CheckResult{Status: health.StatusHealthy}
+		}
+		return &health.CheckResult{Status: health.StatusUnhealthy}
+	})
+
+	b := health.WithCircuitBreaker(inner, health.CircuitBreakerOptions{Failures: 1, Cooldown: 10 * time.Millisecond})
+	ctx := context.Background()
+	_ = b.Check(ctx)
+
+	// a panicking trial counts as a failed one
+	mode.Store(panicking)
+	time.Sleep(20 * time.Millisecond)
+	r := b.Check(ctx)
+	if r.Status != health.StatusUnhealthy || r.Error == nil || r.Error.Error() != "checker panicked: boom" {
+		t.Fatalf("expected panic as an unhealthy result, got %+v", r)
+	}
+	if r.Metadata["circuitBreaker"] != health.BreakerOpen {
+		t.Fatalf("expected panicking trial to reopen the breaker, got %v", r.Metadata)
+	}
+
+	// the next trial still runs, and closes the breaker when it passes
+	mode.Store(passing)
+	time.Sleep(20 * time.Millisecond)
+	r = b.Check(ctx)
+	if calls.Load() != 3 || r.Metadata["circuitBreaker"] != health.BreakerClosed {
+		t.Fatalf("expected a passing trial after the cooldown, got %d calls and %v", calls.Load(), r.Metadata)
+	}
+}
@@ -2,48 +2,206 @@ package health
 
 import (
 	"context"
+	"fmt"
+	"maps"
 	"sync"
 	"time"
 )
 
-// CachedChecker wraps a Checker with TTL-based caching. During refresh,
-// stale values are served to concurrent readers. Only one goroutine
-// refreshes at a time (prevents thundering herd on expensive checks).
+// CachedChecker wraps a Checker with TTL-based caching. Only one refresh runs
+// at a time (prevents thundering herd on expensive checks): callers that find
+// the cache expired wait for the refresh in flight rather than starting
+// their own.
 //
-// The first call always executes the underlying checker synchronously.
+// With WithStaleWhileRevalidate, an expired result is served straight away
+// while a single background refresh runs, up to a maximum staleness after
+// which callers wait as above. The first call, and the first call after
+// Invalidate, always executes the underlying checker synchronously.
+//
+// Results record in Metadata whether they came from the cache, under
+// "cache" as "hit", "stale" or "miss", and how old they are under
+// "cacheAge".
 type CachedChecker struct {
-	inner  Checker
-	ttl    time.Duration
-	mu     sync.RWMutex
-	cached *CheckResult
-	expiry time.Time
+	inner       Checker
+	ttl         time.Duration
+	negativeTTL time.Duration
+	swr         bool
+	maxStale    time.Duration
+
+	mu      sync.Mutex
+	cached  *CheckResult
+	fetched time.Time
+	expiry  time.Time
+	gen     uint64
+	refresh *cacheRefresh
+}
+
+// cacheRefresh is a refresh in flight, which callers can wait on.
+type cacheRefresh struct {
+	gen    uint64
+	done   chan struct{}
+	result *CheckResult
+}
+
+// CacheOption configures a CachedChecker.
+type CacheOption func(*CachedChecker)
+
+// WithStaleWhileRevalidate serves expired results while one background
+// refresh runs. Once a result is more than maxStale past its expiry, callers
+// wait for a refresh instead. A maxStale of zero or less never makes callers
+// wait. The background refresh keeps the values and the remaining deadline
+// of the context that triggered it, but is not cancelled with it.
+func WithStaleWhileRevalidate(maxStale time.Duration) CacheOption {
+	return func(c *CachedChecker) {
+		c.swr = true
+		c.maxStale = maxStale
+	}
+}
+
+// WithNegativeTTL sets how long unhealthy results are cached, so a failing
+// dependency can be rechecked sooner, or later, than a healthy one. Default:
+// the TTL given to WithCache.
+func WithNegativeTTL(ttl time.Duration) CacheOption {
+	return func(c *CachedChecker) {
+		c.negativeTTL = ttl
+	}
 }
 
 // WithCache wraps a Checker with TTL-based result caching.
-func WithCache(c Checker, ttl time.Duration) *CachedChecker {
-	return &CachedChecker{inner: c, ttl: ttl}
+func WithCache(c Checker, ttl time.Duration, opts ...CacheOption) *CachedChecker {
+	cc := &CachedChecker{inner: c, ttl: ttl, negativeTTL: ttl}
+	for _, o := range opts {
+		o(cc)
+	}
+	return cc
 }
 
 // Check returns the cached result if still valid, otherwise refreshes.
 func (c *CachedChecker) Check(ctx context.Context) *CheckResult {
-	c.mu.RLock()
-	if c.cached != nil && time.Now().Before(c.expiry) {
-		result := c.cached
-		c.mu.RUnlock()
-		return result
+	c.mu.Lock()
+	now := time.Now()
+
+	if c.cached != nil && now.Before(c.expiry) {
+		defer c.mu.Unlock()
+		return withCacheState(c.cached, "hit", now.Sub(c.fetched))
+	}
+
+	if c.cached != nil && c.swr && (c.maxStale <= 0 || now.Before(c.expiry.Add(c.maxStale))) {
+		defer c.mu.Unlock()
+		if c.refresh == nil {
+			rctx, cancel := detach(ctx)
+			rf := c.startRefresh()
+			go func() {
+				defer cancel()
+				c.runRefresh(rctx, rf)
+			}()
+		}
+		return withCacheState(c.cached, "stale", now.Sub(c.fetched))
 	}
-	c.mu.RUnlock()
 
+	// join the refresh in flight, or run one
+	if rf := c.refresh; rf != nil {
+		c.mu.Unlock()
+		select {
+		case <-rf.done:
+			return fresh(rf.result)
+		case <-ctx.Done():
+			return &CheckResult{Status: StatusUnhealthy, Error: ctx.Err(), ErrorSince: now, Timestamp: time.Now()}
+		}
+	}
+	rf := c.startRefresh()
+	c.mu.Unlock()
+
+	c.runRefresh(ctx, rf)
+	return fresh(rf.result)
+}
+
+// Invalidate discards the cached result, so the next call runs the
+// underlying checker. A refresh already in flight is not cached when it
+// completes.
+func (c *CachedChecker) Invalidate() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	// double-check after acquiring write lock
-	if c.cached != nil && time.Now().Before(c.expiry) {
-		return c.cached
+	c.cached = nil
+	c.refresh = nil
+	c.gen++
+}
+
+// startRefresh records a new refresh in flight. The caller must hold mu.
+func (c *CachedChecker) startRefresh() *cacheRefresh {
+	rf := &cacheRefresh{gen: c.gen, done: make(chan struct{})}
+	c.refresh = rf
+	return rf
+}
+
+// runRefresh runs the underlying checker and caches its result, unless the
+// cache was invalidated in the meantime. A panic in the checker is reported
+// as an unhealthy result, and the refresh is always released, so waiters
+// are never left blocked.
+func (c *CachedChecker) runRefresh(ctx context.Context, rf *cacheRefresh) {
+	var r *CheckResult
+	defer func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		if r != nil && c.gen == rf.gen {
+			ttl := c.ttl
+			if r.Status == StatusUnhealthy {
+				ttl = c.negativeTTL
+			}
+			c.cached = r
+			c.fetched = time.Now()
+			c.expiry = c.fetched.Add(ttl)
+		}
+		if c.refresh == rf {
+			c.refresh = nil
+		}
+		rf.result = r
+		close(rf.done)
+	}()
+	defer func() {
+		if p := recover(); p != nil {
+			now := time.Now()
+			r = &CheckResult{
+				Status:     StatusUnhealthy,
+				Error:      fmt.Errorf("checker panicked: %v", p),
+				ErrorSince: now,
+				Timestamp:  now,
+			}
+		}
+	}()
+
+	r = c.inner.Check(ctx)
+}
+
+// fresh annotates a result fetched for the caller.
+func fresh(r *CheckResult) *CheckResult {
+	if r == nil {
+		return nil
+	}
+	return withCacheState(r, "miss", 0)
+}
+
+// withCacheState returns a copy of r with its cache state in Metadata,
+// leaving the cached result untouched.
+func withCacheState(r *CheckResult, state string, age time.Duration) *CheckResult {
+	out := *r
+	out.Metadata = maps.Clone(r.Metadata)
+	if out.Metadata == nil {
+		out.Metadata = make(map[string]string, 2)
 	}
+	out.Metadata["cache"] = state
+	out.Metadata["cacheAge"] = age.Round(time.Millisecond).String()
+	return &out
+}
 
-	result := c.inner.Check(ctx)
-	c.cached = result
-	c.expiry = time.Now().Add(c.ttl)
-	return result
+// detach returns a context for a background refresh that is not cancelled
+// with ctx but keeps its values and remaining deadline.
+func detach(ctx context.Context) (context.Context, context.CancelFunc) {
+	bg := context.WithoutCancel(ctx)
+	if deadline, ok := ctx.Deadline(); ok {
+		return context.WithDeadline(bg, deadline)
+	}
+	return bg, func() {}
 }
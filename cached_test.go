@@ -14,7 +14,7 @@ func TestCachedChecker_ReturnsCachedResult(t *testing.T) {
 	var calls atomic.Int32
 	inner := health.CheckerFunc(func(_ context.Context) *health.CheckResult {
 		calls.Add(1)
-		return &health.CheckResult{Name: "test", Status: health.StatusHealthy}
+		return &health.CheckResult{Name: "test", Status: health.StatusHealthy, Timestamp: time.Now()}
 	})
 
 	c := health.WithCache(inner, 500*time.Millisecond)
@@ -30,8 +30,8 @@ func TestCachedChecker_ReturnsCachedResult(t *testing.T) {
 
 	// second call within TTL returns cached
 	r2 := c.Check(context.Background())
-	if r2 != r1 {
-		t.Fatal("expected same pointer for cached result")
+	if r2.Timestamp != r1.Timestamp || r1.Metadata["cache"] != "miss" || r2.Metadata["cache"] != "hit" {
+		t.Fatalf("expected cached result, got %+v", r2)
 	}
 	if calls.Load() != 1 {
 		t.Fatalf("expected still 1 inner call, got %d", calls.Load())
@@ -102,3 +102,176 @@ func TestCachedChecker_FirstCallSynchronous(t *testing.T) {
 		t.Fatalf("expected degraded from first call, got %s", r.Status)
 	}
 }
+
+func TestCachedChecker_StaleWhileRevalidate(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	inner := health.CheckerFunc(func(_ context.Context) *health.CheckResult {
+		if calls.Add(1) > 1 {
+			<-release
+		}
+		return &health.CheckResult{Name: "test", Status: health.StatusHealthy, Timestamp: time.Now()}
+	})
+
+	c := health.WithCache(inner, 20*time.Millisecond, health.WithStaleWhileRevalidate(time.Second))
+	first := c.Check(context.Background())
+	time.Sleep(30 * time.Millisecond)
+
+	// the expired result is served while a single refresh is blocked
+	for i := 0; i < 5; i++ {
+		r := c.Check(context.Background())
+		if r.Metadata["cache"] != "stale" || r.Timestamp != first.Timestamp {
+			t.Fatalf("expected stale result, got %+v", r)
+		}
+	}
+	time.Sleep(10 * time.Millisecond)
+	if n := calls.Load(); n != 2 {
+		t.Fatalf("expected one background refresh, got %d calls", n-1)
+	}
+
+	close(release)
+	deadline := time.Now().Add(time.Second)
+	for c.Check(context.Background()).Metadata["cache"] != "hit" {
+		if time.Now().After(deadline) {
+			t.Fatal("expected refreshed result to be cached")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestCachedChecker_MaxStale(t *testing.T) {
+	var calls atomic.Int32
+	inner := health.CheckerFunc(func(_ context.Context) *health.CheckResult {
+		calls.Add(1)
+		return &health.CheckResult{Name: "test", Status: health.StatusHealthy}
+	})
+
+	c := health.WithCache(inner, 10*time.Millisecond, health.WithStaleWhileRevalidate(10*time.Millisecond))
+	c.Check(context.Background())
+	time.Sleep(30 * time.Millisecond)
+
+	// past the staleness bound the caller waits for a fresh result
+	if r := c.Check(context.Background()); r.Metadata["cache"] != "miss" {
+		t.Fatalf("expected a synchronous refresh past max staleness, got %v", r.Metadata)
+	}
+	if calls.Load() != 2 {
+		t.Fatalf("expected 2 calls, got %d", calls.Load())
+	}
+}
+
+func TestCachedChecker_NegativeTTL(t *testing.T) {
+	var calls atomic.Int32
+	inner := health.CheckerFunc(func(_ context.Context) *health.CheckResult {
+		calls.Add(1)
+		return &health.CheckResult{Name: "test", Status: health.StatusUnhealthy}
+	})
+
+	c := health.WithCache(inner, time.Minute, health.WithNegativeTTL(10*time.Millisecond))
+	c.Check(context.Background())
+	c.Check(context.Background())
+	if calls.Load() != 1 {
+		t.Fatalf("expected unhealthy result to be cached, got %d calls", calls.Load())
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	c.Check(context.Background())
+	if calls.Load() != 2 {
+		t.Fatalf("expected unhealthy result to expire after the negative TTL, got %d calls", calls.Load())
+	}
+}
+
+func TestCachedChecker_Invalidate(t *testing.T) {
+	var calls atomic.Int32
+	inner := health.CheckerFunc(func(_ context.Context) *health.CheckResult {
+		calls.Add(1)
+		return &health.CheckResult{Name: "test", Status: health.StatusHealthy}
+	})
+
+	c := health.WithCache(inner, time.Minute)
+	c.Check(context.Background())
+	c.Invalidate()
+
+	r := c.Check(context.Background())
+	if calls.Load() != 2 || r.Metadata["cache"] != "miss" {
+		t.Fatalf("expected invalidated cache to refresh, got %d calls and %v", calls.Load(), r.Metadata)
+	}
+	if r = c.Check(context.Background()); r.Metadata["cache"] != "hit" || r.Metadata["cacheAge"] == "" {
+		t.Fatalf("expected cache hit with age, got %v", r.Metadata)
+	}
+}
+
+func TestCachedChecker_WaitHonorsContext(t *testing.T) {
+	release := make(chan struct{})
+	var calls atomic.Int32
+	inner := health.CheckerFunc(func(_ context.Context) *health.CheckResult {
+		if calls.Add(1) > 1 {
+			<-release
+		}
+		return &health.CheckResult{Name: "test", Status: health.StatusHealthy}
+	})
+
+	c := health.WithCache(inner, time.Minute)
+	c.Check(context.Background())
+	c.Invalidate()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		c.Check(context.Background())
+	}()
+	for calls.Load() < 2 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// a caller with a deadline gives up instead of queueing behind the refresh
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if r := c.Check(ctx); r.Status != health.StatusUnhealthy || r.Error == nil {
+		t.Fatalf("expected waiting caller to honor its context, got %+v", r)
+	}
+	if calls.Load() != 2 {
+		t.Fatalf("expected the waiting caller to join the refresh in flight, got %d calls", calls.Load())
+	}
+
+	close(release)
+	<-done
+}
+
+func TestCachedChecker_Panic(t *testing.T) {
+	var calls atomic.Int32
+	inner := health.CheckerFunc(func(_ context.Context) *health.CheckResult {
+		if calls.Add(1) != 2 {
+			panic("boom")
+		}
+		return &health.CheckResult{Name: "test", Status: health.StatusHealthy, Timestamp: time.Now()}
+	})
+
+	c := health.WithCache(inner, 20*time.Millisecond, health.WithStaleWhileRevalidate(time.Second))
+	r := c.Check(context.Background())
+	if r.Status != health.StatusUnhealthy || r.Error == nil || r.Error.Error() != "checker panicked: boom" {
+		t.Fatalf("expected panic reported as unhealthy, got %+v", r)
+	}
+
+	// the cache is released: the next refresh calls the checker again
+	time.Sleep(30 * time.Millisecond)
+	_ = c.Check(context.Background())
+	deadline := time.Now().Add(time.Second)
+	for c.Check(context.Background()).Status != health.StatusHealthy {
+		if time.Now().After(deadline) {
+			t.Fatal("expected refresh after a panic")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	// a panic in the background refresh is recovered too
+	time.Sleep(30 * time.Millisecond)
+	if r := c.Check(context.Background()); r.Metadata["cache"] != "stale" {
+		t.Fatalf("expected stale result, got %+v", r)
+	}
+	for c.Check(context.Background()).Status != health.StatusUnhealthy {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the recovered panic to be cached")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
@@ -15,6 +15,11 @@ const (
 
 	// StatusUnhealthy indicates the check is failing.
 	StatusUnhealthy
+
+	// StatusSkipped indicates the check was not run, for example because a
+	// check it depends on is failing. Skipped checks do not fail liveness or
+	// readiness probes on their own, but do not count as passing for startup.
+	StatusSkipped
 )
 
 // String returns the lowercase string representation of a Status.
@@ -26,6 +31,8 @@ func (s Status) String() string {
 		return "degraded"
 	case StatusUnhealthy:
 		return "unhealthy"
+	case StatusSkipped:
+		return "skipped"
 	default:
 		return "unknown"
 	}
@@ -35,7 +42,8 @@ func (s Status) String() string {
 //
 // Some fields are set by the checker (Status, Error, Duration, Timestamp, Metadata),
 // while others are overridden by the manager from the registered [AddCheckOptions]
-// (Name, AffectsLiveness, AffectsReadiness, AffectsStartup, Group, ComponentType, DependsOn).
+// (Name, AffectsLiveness, AffectsReadiness, AffectsStartup, Group, ComponentType, DependsOn)
+// or computed by the manager (History).
 type CheckResult struct {
 	// Name identifies the check. Set by the manager from the registered check name.
 	Name string
@@ -63,4 +71,26 @@ type CheckResult struct {
 	Metadata map[string]string
 	// Timestamp is when this check result was produced. Set by checker.
 	Timestamp time.Time
+	// History summarizes the recent executions of this check. Set by manager.
+	History *CheckHistory
+}
+
+// CheckExecution records a single execution of a check.
+type CheckExecution struct {
+	Status    Status
+	Duration  time.Duration
+	Error     error
+	Timestamp time.Time
+}
+
+// CheckHistory summarizes the recent executions of a check.
+type CheckHistory struct {
+	// Executions are the most recently recorded executions, oldest first.
+	Executions []CheckExecution
+	// Uptime is the fraction of executions within the window that passed.
+	// Healthy and degraded executions pass.
+	Uptime float64
+	// Flaps is the number of times the check changed between passing and
+	// failing within the window.
+	Flaps int
 }
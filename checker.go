@@ -24,6 +24,23 @@ type AddCheckOptions struct {
 	Group            string
 	ComponentType    string
 	DependsOn        []string
+	FailureThreshold int
+	SuccessThreshold int
+	Timeout          time.Duration
+
+	// Jitter is the fraction of the interval by which each wait is randomly
+	// lengthened or shortened when CheckWithJitter is set.
+	Jitter float64
+	// BackoffInterval, BackoffMultiplier and BackoffMaxInterval shape the
+	// waits between executions of a failing check when CheckWithBackoff is set.
+	BackoffInterval    time.Duration
+	BackoffMultiplier  float64
+	BackoffMaxInterval time.Duration
+
+	// FlapWindow and FlapThreshold control flap detection; see
+	// WithFlapDetection.
+	FlapWindow    time.Duration
+	FlapThreshold int
 }
 
 // AddCheckOption is a functional option for adding a Checker to a health manager.
@@ -46,22 +63,68 @@ const (
 	// CheckAfter instructs the Checker to wait until after a specified time to
 	// perform its check.
 	CheckAfter
+
+	// CheckWithJitter modifies CheckAtInterval so that each wait, including
+	// the first, is randomly lengthened or shortened by up to a fraction of the
+	// interval. This spreads out checks from replicas that started together.
+	// The fraction defaults to DefaultJitter and is set with WithJitter.
+	CheckWithJitter
+
+	// CheckWithBackoff modifies CheckAtInterval so that a failing check is
+	// rescheduled on a separate, exponentially growing interval until it
+	// passes again. The backoff defaults to doubling from the check interval
+	// up to ten times the check interval, and is set with WithBackoff.
+	CheckWithBackoff
 )
 
+// DefaultJitter is the jitter fraction used when CheckWithJitter is set
+// without a fraction.
+const DefaultJitter = 0.1
+
+// DefaultCheckInterval is the interval used when CheckAtInterval is set
+// with an interval equal to or less than zero.
+const DefaultCheckInterval = 10 * time.Second
+
 // WithCheckFrequency tells the health instance the CheckFrequency at which it will perform check with the specified Checker
 // instance. If the value for CheckFrequency is CheckOnce, the Interval parameter is ignored. If the value for
 // CheckFrequency is CheckAtInterval, the value of Interval will be used. If the value of Interval is equal to or less
-// than zero, then the default Interval is used. If the value of Delay is equal to or less than zero, it is ignored.
+// than zero, then DefaultCheckInterval is used. If the value of Delay is equal to or less than zero, it is ignored.
 // This option is not additive, so multiple invocations of this option will result in the last invocation being used to
-// configure the Checker.
+// configure the Checker. CheckWithJitter and CheckWithBackoff set by WithJitter or WithBackoff are preserved.
 func WithCheckFrequency(f CheckFrequency, interval, delay time.Duration) AddCheckOption {
 	return func(o *AddCheckOptions) {
-		o.Frequency = f
+		o.Frequency = f | o.Frequency&(CheckWithJitter|CheckWithBackoff)
 		o.Interval = interval
 		o.Delay = delay
 	}
 }
 
+// WithJitter sets CheckWithJitter on an interval check, randomly lengthening
+// or shortening each wait by up to fraction of the interval. The fraction is
+// clamped to [0, 1]; zero means DefaultJitter.
+func WithJitter(fraction float64) AddCheckOption {
+	return func(o *AddCheckOptions) {
+		o.Frequency |= CheckWithJitter
+		o.Jitter = fraction
+	}
+}
+
+// WithBackoff sets CheckWithBackoff on an interval check. After the first
+// failure, the check is retried after initial, and each further consecutive
+// failure multiplies the wait by multiplier, up to maxInterval. Once the check
+// passes, the regular interval resumes. An initial shorter than the check
+// interval retries a failing dependency faster; a longer one backs off from
+// it. Values equal to or less than zero use the defaults described on
+// CheckWithBackoff; a multiplier of 1 retries at a constant rate.
+func WithBackoff(initial, maxInterval time.Duration, multiplier float64) AddCheckOption {
+	return func(o *AddCheckOptions) {
+		o.Frequency |= CheckWithBackoff
+		o.BackoffInterval = initial
+		o.BackoffMaxInterval = maxInterval
+		o.BackoffMultiplier = multiplier
+	}
+}
+
 // WithLivenessImpact marks a health check as affecting the liveness of the application.
 // If a check that affects liveness fails, readiness is also affected.
 func WithLivenessImpact() AddCheckOption {
@@ -103,9 +166,59 @@ func WithComponentType(ct string) AddCheckOption {
 }
 
 // WithDependsOn declares that this check depends on other named checks.
-// Used by the discovery protocol to build dependency graphs.
+// Used by the discovery protocol to build dependency graphs. Entries that name
+// another check registered with the same manager are local dependencies: the
+// std.Manager runs a check only after its local dependencies have reported,
+// and reports it as StatusSkipped instead of running it while any of them is
+// failing. Cycles among local dependencies are rejected when the manager runs.
 func WithDependsOn(deps ...string) AddCheckOption {
 	return func(o *AddCheckOptions) {
 		o.DependsOn = append(o.DependsOn, deps...)
 	}
 }
+
+// WithFailureThreshold sets how many consecutive failed executions are needed
+// before a check is considered unhealthy, mirroring the Kubernetes probe field
+// of the same name. Failures below the threshold are reported as degraded and
+// do not affect liveness or readiness. As with Kubernetes probes, a check
+// counts as failed until it first passes, so failures before then are not
+// tolerated. Values below 1 are treated as 1, which is the default.
+func WithFailureThreshold(n int) AddCheckOption {
+	return func(o *AddCheckOptions) {
+		o.FailureThreshold = n
+	}
+}
+
+// WithSuccessThreshold sets how many consecutive passing executions are needed
+// before an unhealthy check is considered healthy again, mirroring the
+// Kubernetes probe field of the same name. Passes below the threshold are
+// still reported as unhealthy. Values below 1 are treated as 1, which is the
+// default.
+func WithSuccessThreshold(n int) AddCheckOption {
+	return func(o *AddCheckOptions) {
+		o.SuccessThreshold = n
+	}
+}
+
+// WithCheckTimeout sets a deadline for each execution of a check. The manager
+// passes the checker a context with this deadline and, if the checker has not
+// returned by then, abandons it and reports the check as unhealthy. This
+// overrides any manager-wide default. Values equal to or less than zero are
+// ignored.
+func WithCheckTimeout(d time.Duration) AddCheckOption {
+	return func(o *AddCheckOptions) {
+		o.Timeout = d
+	}
+}
+
+// WithFlapDetection reports an otherwise healthy check as degraded while it
+// has changed between passing and failing more than threshold times within
+// the window. The window also bounds the uptime ratio and flap count reported
+// in [CheckResult.History]; a window of zero covers the whole recorded
+// history. A threshold of zero disables flap detection.
+func WithFlapDetection(window time.Duration, threshold int) AddCheckOption {
+	return func(o *AddCheckOptions) {
+		o.FlapWindow = window
+		o.FlapThreshold = threshold
+	}
+}
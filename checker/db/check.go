@@ -23,6 +23,7 @@ type Checker struct {
 	name    string
 	pinger  CtxPinger
 	timeout time.Duration
+	latency health.LatencyThresholds
 }
 
 // Option is a functional decorator for creating a new Checker.
@@ -35,6 +36,15 @@ func WithTimeout(timeout time.Duration) Option {
 	}
 }
 
+// WithLatencyThresholds reports a successful check as degraded when it takes
+// longer than warning, and as unhealthy when it takes longer than critical.
+// Zero disables either threshold.
+func WithLatencyThresholds(warning, critical time.Duration) Option {
+	return func(c *Checker) {
+		c.latency = health.LatencyThresholds{Warning: warning, Critical: critical}
+	}
+}
+
 // NewChecker returns a Checker using the provided name and CtxPinger.
 func NewChecker(name string, pinger CtxPinger, opts ...Option) *Checker {
 	out := Checker{
@@ -50,7 +60,12 @@ func NewChecker(name string, pinger CtxPinger, opts ...Option) *Checker {
 	return &out
 }
 
+// Check satisfies health.Checker.
 func (c *Checker) Check(ctx context.Context) *health.CheckResult {
+	return c.latency.Apply(c.check(ctx))
+}
+
+func (c *Checker) check(ctx context.Context) *health.CheckResult {
 	now := time.Now()
 	select {
 	case <-ctx.Done():
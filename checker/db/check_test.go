@@ -114,3 +114,28 @@ func TestCheck_Timeout(t *testing.T) {
 		t.Errorf("expected deadline exceeded, got %v", result.Error)
 	}
 }
+
+func TestCheck_LatencyThresholds(t *testing.T) {
+	tests := []struct {
+		name  string
+		delay time.Duration
+		want  health.Status
+	}{
+		{"fast", 0, health.StatusHealthy},
+		{"slow", 30 * time.Millisecond, health.StatusDegraded},
+		{"too slow", 80 * time.Millisecond, health.StatusUnhealthy},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewChecker("test", &mockPinger{delay: tt.delay}, WithLatencyThresholds(20*time.Millisecond, 60*time.Millisecond))
+			result := c.Check(context.Background())
+			if result.Status != tt.want {
+				t.Fatalf("expected %s, got %s (err: %v)", tt.want, result.Status, result.Error)
+			}
+			if tt.want != health.StatusHealthy && result.Metadata["latencyThreshold"] == "" {
+				t.Fatalf("expected latency metadata, got %v", result.Metadata)
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@ type Checker struct {
 	hostname string
 	resolver *net.Resolver
 	timeout  time.Duration
+	latency  health.LatencyThresholds
 }
 
 // Option is a functional option for configuring a DNS Checker.
@@ -27,6 +28,15 @@ func WithTimeout(d time.Duration) Option {
 	return func(c *Checker) { c.timeout = d }
 }
 
+// WithLatencyThresholds reports a successful check as degraded when it takes
+// longer than warning, and as unhealthy when it takes longer than critical.
+// Zero disables either threshold.
+func WithLatencyThresholds(warning, critical time.Duration) Option {
+	return func(c *Checker) {
+		c.latency = health.LatencyThresholds{Warning: warning, Critical: critical}
+	}
+}
+
 // WithResolver sets a custom net.Resolver.
 func WithResolver(r *net.Resolver) Option {
 	return func(c *Checker) { c.resolver = r }
@@ -44,7 +54,12 @@ func NewChecker(name, hostname string, opts ...Option) *Checker {
 	return c
 }
 
+// Check satisfies health.Checker.
 func (c *Checker) Check(ctx context.Context) *health.CheckResult {
+	return c.latency.Apply(c.check(ctx))
+}
+
+func (c *Checker) check(ctx context.Context) *health.CheckResult {
 	start := time.Now()
 	ctx, cancel := context.WithTimeout(ctx, c.timeout)
 	defer cancel()
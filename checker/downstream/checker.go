@@ -0,0 +1,175 @@
+package downstream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/schigh/health/v2"
+	"github.com/schigh/health/v2/discovery"
+)
+
+const DefaultTimeout = discovery.DefaultTimeout
+
+// Checker checks a peer service through the health manifest it serves at
+// /.well-known/health. The peer's "pass", "warn" and "fail" statuses are
+// reported as healthy, degraded and unhealthy, and the names of the peer's
+// failing and degraded checks are recorded in Metadata under "failing" and
+// "degraded".
+type Checker struct {
+	name     string
+	baseURL  string
+	client   *http.Client
+	timeout  time.Duration
+	required []string
+	latency  health.LatencyThresholds
+}
+
+// Option is a functional option for configuring a downstream Checker.
+type Option func(*Checker)
+
+// WithTimeout sets the timeout for fetching the manifest.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Checker) { c.timeout = d }
+}
+
+// WithLatencyThresholds reports a successful check as degraded when it takes
+// longer than warning, and as unhealthy when it takes longer than critical.
+// Zero disables either threshold.
+func WithLatencyThresholds(warning, critical time.Duration) Option {
+	return func(c *Checker) {
+		c.latency = health.LatencyThresholds{Warning: warning, Critical: critical}
+	}
+}
+
+// WithClient sets a custom http.Client.
+func WithClient(client *http.Client) Option {
+	return func(c *Checker) { c.client = client }
+}
+
+// WithRequiredChecks requires the peer to report the named checks. A
+// required check that is missing or unhealthy makes the result unhealthy,
+// and one that is degraded makes it degraded, whatever the peer's overall
+// status.
+func WithRequiredChecks(names ...string) Option {
+	return func(c *Checker) { c.required = append(c.required, names...) }
+}
+
+// NewChecker returns a checker for the peer service at baseURL, such as
+// "http://payments:8181".
+func NewChecker(name, baseURL string, opts ...Option) *Checker {
+	c := &Checker{name: name, baseURL: baseURL, timeout: DefaultTimeout}
+	for _, o := range opts {
+		o(c)
+	}
+	return c
+}
+
+// Check satisfies health.Checker.
+func (c *Checker) Check(ctx context.Context) *health.CheckResult {
+	return c.latency.Apply(c.check(ctx))
+}
+
+func (c *Checker) check(ctx context.Context) *health.CheckResult {
+	start := time.Now()
+
+	opts := []discovery.DiscoverOption{discovery.WithTimeout(c.timeout)}
+	if c.client != nil {
+		opts = append(opts, discovery.WithClient(c.client))
+	}
+	m, err := discovery.FetchManifest(ctx, c.baseURL, opts...)
+	if err != nil {
+		return &health.CheckResult{
+			Name:      c.name,
+			Status:    health.StatusUnhealthy,
+			Error:     err,
+			Duration:  time.Since(start),
+			Timestamp: start,
+		}
+	}
+
+	hc := &health.CheckResult{
+		Name:      c.name,
+		Timestamp: start,
+		Metadata:  map[string]string{"status": m.Status},
+	}
+	if m.Service != "" {
+		hc.Metadata["service"] = m.Service
+	}
+
+	var failing, degraded []string
+	for _, entry := range m.Checks {
+		switch entry.Status {
+		case health.StatusHealthy.String():
+		case health.StatusDegraded.String():
+			degraded = append(degraded, entry.Name)
+		default:
+			failing = append(failing, entry.Name)
+		}
+	}
+	if len(failing) > 0 {
+		hc.Metadata["failing"] = strings.Join(failing, ",")
+	}
+	if len(degraded) > 0 {
+		hc.Metadata["degraded"] = strings.Join(degraded, ",")
+	}
+
+	var errs []error
+	switch m.Status {
+	case "pass":
+		hc.Status = health.StatusHealthy
+	case "warn":
+		hc.Status = health.StatusDegraded
+	case "fail":
+		hc.Status = health.StatusUnhealthy
+		errs = append(errs, peerError(m, failing))
+	default:
+		hc.Status = health.StatusUnhealthy
+		errs = append(errs, fmt.Errorf("unknown status %q", m.Status))
+	}
+
+	for _, name := range c.required {
+		status, ok := checkStatus(m, name)
+		switch {
+		case !ok:
+			hc.Status = health.StatusUnhealthy
+			errs = append(errs, fmt.Errorf("required check %s not reported", name))
+		case status == health.StatusHealthy.String():
+		case status == health.StatusDegraded.String():
+			if hc.Status == health.StatusHealthy {
+				hc.Status = health.StatusDegraded
+			}
+		default:
+			hc.Status = health.StatusUnhealthy
+			errs = append(errs, fmt.Errorf("required check %s is %s", name, status))
+		}
+	}
+
+	hc.Error = errors.Join(errs...)
+	hc.Duration = time.Since(start)
+	return hc
+}
+
+// peerError describes a failing peer, naming its failing checks.
+func peerError(m *discovery.Manifest, failing []string) error {
+	service := m.Service
+	if service == "" {
+		service = "service"
+	}
+	if len(failing) == 0 {
+		return fmt.Errorf("%s is failing", service)
+	}
+	return fmt.Errorf("%s is failing: %s", service, strings.Join(failing, ", "))
+}
+
+func checkStatus(m *discovery.Manifest, name string) (string, bool) {
+	for _, entry := range m.Checks {
+		if entry.Name == name {
+			return entry.Status, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,136 @@
+package downstream_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/schigh/health/v2"
+	"github.com/schigh/health/v2/checker/downstream"
+	"github.com/schigh/health/v2/discovery"
+)
+
+func serveManifest(m discovery.Manifest) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != discovery.WellKnownPath {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(m)
+	}))
+}
+
+func TestChecker_Status(t *testing.T) {
+	tests := []struct {
+		status string
+		want   health.Status
+	}{
+		{"pass", health.StatusHealthy},
+		{"warn", health.StatusDegraded},
+		{"fail", health.StatusUnhealthy},
+		{"bogus", health.StatusUnhealthy},
+	}
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			srv := serveManifest(discovery.Manifest{Service: "payments", Status: tt.status})
+			defer srv.Close()
+
+			result := downstream.NewChecker("payments", srv.URL).Check(context.Background())
+			if result.Status != tt.want {
+				t.Fatalf("expected %s, got %s (err: %v)", tt.want, result.Status, result.Error)
+			}
+			if result.Metadata["service"] != "payments" || result.Metadata["status"] != tt.status {
+				t.Fatalf("expected peer service and status in metadata, got %v", result.Metadata)
+			}
+		})
+	}
+}
+
+func TestChecker_FailingChecks(t *testing.T) {
+	srv := serveManifest(discovery.Manifest{
+		Service: "payments",
+		Status:  "fail",
+		Checks: []discovery.CheckEntry{
+			{Name: "postgres", Status: "unhealthy", Error: "connection refused"},
+			{Name: "stripe", Status: "degraded"},
+			{Name: "redis", Status: "healthy"},
+			{Name: "kafka", Status: "unhealthy"},
+		},
+	})
+	defer srv.Close()
+
+	result := downstream.NewChecker("payments", srv.URL).Check(context.Background())
+	if result.Status != health.StatusUnhealthy {
+		t.Fatalf("expected unhealthy, got %s", result.Status)
+	}
+	if result.Metadata["failing"] != "postgres,kafka" || result.Metadata["degraded"] != "stripe" {
+		t.Fatalf("expected failing and degraded checks in metadata, got %v", result.Metadata)
+	}
+	if result.Error == nil || result.Error.Error() != "payments is failing: postgres, kafka" {
+		t.Fatalf("expected error naming failing checks, got %v", result.Error)
+	}
+}
+
+func TestChecker_RequiredChecks(t *testing.T) {
+	srv := serveManifest(discovery.Manifest{
+		Service: "payments",
+		Status:  "pass",
+		Checks: []discovery.CheckEntry{
+			{Name: "postgres", Status: "healthy"},
+			{Name: "stripe", Status: "degraded"},
+			{Name: "ledger", Status: "unhealthy"},
+		},
+	})
+	defer srv.Close()
+
+	tests := []struct {
+		name     string
+		required []string
+		want     health.Status
+		errMsg   string
+	}{
+		{"healthy", []string{"postgres"}, health.StatusHealthy, ""},
+		{"degraded", []string{"postgres", "stripe"}, health.StatusDegraded, ""},
+		{"unhealthy", []string{"ledger"}, health.StatusUnhealthy, "required check ledger is unhealthy"},
+		{"missing", []string{"kafka"}, health.StatusUnhealthy, "required check kafka not reported"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := downstream.NewChecker("payments", srv.URL, downstream.WithRequiredChecks(tt.required...))
+			result := c.Check(context.Background())
+			if result.Status != tt.want {
+				t.Fatalf("expected %s, got %s (err: %v)", tt.want, result.Status, result.Error)
+			}
+			if tt.errMsg == "" && result.Error != nil {
+				t.Fatalf("expected no error, got %v", result.Error)
+			}
+			if tt.errMsg != "" && (result.Error == nil || result.Error.Error() != tt.errMsg) {
+				t.Fatalf("expected error %q, got %v", tt.errMsg, result.Error)
+			}
+		})
+	}
+}
+
+func TestChecker_Unreachable(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	result := downstream.NewChecker("payments", srv.URL).Check(context.Background())
+	if result.Status != health.StatusUnhealthy {
+		t.Fatalf("expected unhealthy, got %s", result.Status)
+	}
+	if result.Error == nil || !strings.Contains(result.Error.Error(), "status 404") {
+		t.Fatalf("expected fetch error, got %v", result.Error)
+	}
+
+	result = downstream.NewChecker("payments", "http://127.0.0.1:1",
+		downstream.WithTimeout(100*time.Millisecond)).Check(context.Background())
+	if result.Status != health.StatusUnhealthy || result.Error == nil {
+		t.Fatalf("expected unhealthy with error, got %+v", result)
+	}
+}
@@ -1,24 +1,58 @@
 package http
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/schigh/health/v2"
 )
 
-const DefaultTimeout = 5 * time.Second
+const (
+	DefaultTimeout = 5 * time.Second
+
+	// DefaultSnippetSize is how much of the response body is recorded in
+	// the result Metadata when a check fails.
+	DefaultSnippetSize = 256
+
+	// MaxBodySize is how much of the response body is read for body
+	// assertions. Anything beyond it is ignored.
+	MaxBodySize = 1 << 20
+)
 
 // Checker performs HTTP health checks against a URL endpoint.
 type Checker struct {
-	name           string
-	url            string
-	client         *http.Client
-	timeout        time.Duration
-	expectedStatus int
-	method         string
+	name        string
+	url         string
+	client      *http.Client
+	timeout     time.Duration
+	statuses    []statusRange
+	method      string
+	header      http.Header
+	body        []byte
+	snippetSize int
+	assertions  []assertion
+	latency     health.LatencyThresholds
+}
+
+// statusRange is an inclusive range of accepted status codes.
+type statusRange struct {
+	lo, hi int
+}
+
+// assertion checks a response. body is nil unless the checker has body
+// assertions.
+type assertion struct {
+	needsBody bool
+	check     func(resp *http.Response, body []byte) error
 }
 
 // Option is a functional option for configuring an HTTP Checker.
@@ -29,9 +63,34 @@ func WithTimeout(d time.Duration) Option {
 	return func(c *Checker) { c.timeout = d }
 }
 
-// WithExpectedStatus sets the expected HTTP status code. Default is 200.
+// WithLatencyThresholds reports a successful check as degraded when it takes
+// longer than warning, and as unhealthy when it takes longer than critical.
+// Zero disables either threshold.
+func WithLatencyThresholds(warning, critical time.Duration) Option {
+	return func(c *Checker) {
+		c.latency = health.LatencyThresholds{Warning: warning, Critical: critical}
+	}
+}
+
+// WithExpectedStatus adds an accepted HTTP status code. Default is 200 when
+// no accepted status is set.
 func WithExpectedStatus(code int) Option {
-	return func(c *Checker) { c.expectedStatus = code }
+	return WithExpectedStatusRange(code, code)
+}
+
+// WithExpectedStatuses adds accepted HTTP status codes.
+func WithExpectedStatuses(codes ...int) Option {
+	return func(c *Checker) {
+		for _, code := range codes {
+			c.statuses = append(c.statuses, statusRange{code, code})
+		}
+	}
+}
+
+// WithExpectedStatusRange adds an inclusive range of accepted HTTP status
+// codes, such as 200 to 299.
+func WithExpectedStatusRange(lo, hi int) Option {
+	return func(c *Checker) { c.statuses = append(c.statuses, statusRange{lo, hi}) }
 }
 
 // WithMethod sets the HTTP method. Default is GET.
@@ -44,30 +103,133 @@ func WithClient(client *http.Client) Option {
 	return func(c *Checker) { c.client = client }
 }
 
+// WithHeader adds a header to the request.
+func WithHeader(key, value string) Option {
+	return func(c *Checker) { c.header.Add(key, value) }
+}
+
+// WithBody sets the request body, for health endpoints that expect a POST.
+// Set the Content-Type with WithHeader.
+func WithBody(body []byte) Option {
+	return func(c *Checker) { c.body = body }
+}
+
+// WithBodySnippet sets how many bytes of the response body are recorded in
+// the result Metadata under "body" when a check fails. Zero disables it.
+// Default is DefaultSnippetSize.
+func WithBodySnippet(n int) Option {
+	return func(c *Checker) { c.snippetSize = n }
+}
+
+// WithBodyContains requires the response body to contain substr.
+func WithBodyContains(substr string) Option {
+	return withBodyAssertion(func(body []byte) error {
+		if !bytes.Contains(body, []byte(substr)) {
+			return fmt.Errorf("expected body to contain %q", substr)
+		}
+		return nil
+	})
+}
+
+// WithBodyMatches requires the response body to match re.
+func WithBodyMatches(re *regexp.Regexp) Option {
+	return withBodyAssertion(func(body []byte) error {
+		if !re.Match(body) {
+			return fmt.Errorf("expected body to match %q", re)
+		}
+		return nil
+	})
+}
+
+// WithJSONPathEquals requires the response body to be JSON with the value
+// at path equal to want. The path is a dot-separated list of object keys and
+// array indexes, such as "checks.0.status". Strings are compared as they
+// are; other values are compared by their JSON encoding, such as "true" or
+// "3".
+func WithJSONPathEquals(path, want string) Option {
+	return withBodyAssertion(func(body []byte) error {
+		v, err := jsonPath(body, path)
+		if err != nil {
+			return err
+		}
+		if got := jsonString(v); got != want {
+			return fmt.Errorf("expected %s to be %q, got %q", path, want, got)
+		}
+		return nil
+	})
+}
+
+// WithJSONPathExists requires the response body to be JSON with a value at
+// path. See WithJSONPathEquals for the path syntax.
+func WithJSONPathExists(path string) Option {
+	return withBodyAssertion(func(body []byte) error {
+		_, err := jsonPath(body, path)
+		return err
+	})
+}
+
+// WithResponseHeader requires the response to have the header key. If value
+// is not empty, the header must also equal it.
+func WithResponseHeader(key, value string) Option {
+	return func(c *Checker) {
+		c.assertions = append(c.assertions, assertion{check: func(resp *http.Response, _ []byte) error {
+			got, ok := resp.Header[http.CanonicalHeaderKey(key)]
+			switch {
+			case !ok:
+				return fmt.Errorf("expected header %s", key)
+			case value != "" && !slices.Contains(got, value):
+				return fmt.Errorf("expected header %s to be %q, got %q", key, value, strings.Join(got, ", "))
+			}
+			return nil
+		}})
+	}
+}
+
+func withBodyAssertion(fn func(body []byte) error) Option {
+	return func(c *Checker) {
+		c.assertions = append(c.assertions, assertion{needsBody: true, check: func(_ *http.Response, body []byte) error {
+			return fn(body)
+		}})
+	}
+}
+
 // NewChecker returns an HTTP health checker for the given URL.
 func NewChecker(name, url string, opts ...Option) *Checker {
 	c := &Checker{
-		name:           name,
-		url:            url,
-		timeout:        DefaultTimeout,
-		expectedStatus: http.StatusOK,
-		method:         http.MethodGet,
+		name:        name,
+		url:         url,
+		timeout:     DefaultTimeout,
+		method:      http.MethodGet,
+		header:      make(http.Header),
+		snippetSize: DefaultSnippetSize,
 	}
 	for _, o := range opts {
 		o(c)
 	}
+	if len(c.statuses) == 0 {
+		c.statuses = []statusRange{{http.StatusOK, http.StatusOK}}
+	}
 	if c.client == nil {
 		c.client = &http.Client{Timeout: c.timeout}
 	}
 	return c
 }
 
+// Check satisfies health.Checker.
 func (c *Checker) Check(ctx context.Context) *health.CheckResult {
+	return c.latency.Apply(c.check(ctx))
+}
+
+func (c *Checker) check(ctx context.Context) *health.CheckResult {
 	start := time.Now()
 	ctx, cancel := context.WithTimeout(ctx, c.timeout)
 	defer cancel()
 
-	req, err := http.NewRequestWithContext(ctx, c.method, c.url, http.NoBody)
+	var reqBody io.Reader = http.NoBody
+	if c.body != nil {
+		reqBody = bytes.NewReader(c.body)
+	}
+	req, err := http.NewRequestWithContext(ctx, c.method, c.url, reqBody)
 	if err != nil {
 		return &health.CheckResult{
 			Name:      c.name,
@@ -77,6 +239,12 @@ func (c *Checker) Check(ctx context.Context) *health.CheckResult {
 			Timestamp: start,
 		}
 	}
+	for key, values := range c.header {
+		req.Header[key] = values
+	}
+	if host := c.header.Get("Host"); host != "" {
+		req.Host = host
+	}
 
 	resp, err := c.client.Do(req)
 	if err != nil {
@@ -90,13 +258,23 @@ func (c *Checker) Check(ctx context.Context) *health.CheckResult {
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != c.expectedStatus {
-		return &health.CheckResult{
-			Name:      c.name,
-			Status:    health.StatusUnhealthy,
-			Error:     fmt.Errorf("expected status %d, got %d", c.expectedStatus, resp.StatusCode),
-			Duration:  time.Since(start),
-			Timestamp: start,
+	var body []byte
+	if c.needsBody() {
+		if body, err = io.ReadAll(io.LimitReader(resp.Body, MaxBodySize)); err != nil {
+			return c.failed(start, resp, body, fmt.Errorf("read body: %w", err))
+		}
+	}
+
+	if !c.acceptsStatus(resp.StatusCode) {
+		err := fmt.Errorf("unexpected status %d", resp.StatusCode)
+		if len(c.statuses) == 1 && c.statuses[0].lo == c.statuses[0].hi {
+			err = fmt.Errorf("expected status %d, got %d", c.statuses[0].lo, resp.StatusCode)
+		}
+		return c.failed(start, resp, body, err)
+	}
+	for _, a := range c.assertions {
+		if err := a.check(resp, body); err != nil {
+			return c.failed(start, resp, body, err)
 		}
 	}
 
@@ -107,3 +285,91 @@ func (c *Checker) Check(ctx context.Context) *health.CheckResult {
 		Timestamp: start,
 	}
 }
+
+// failed returns an unhealthy result recording the status code and, unless
+// disabled, a snippet of the body. If the body has not been read, the
+// snippet is read now.
+func (c *Checker) failed(start time.Time, resp *http.Response, body []byte, err error) *health.CheckResult {
+	hc := &health.CheckResult{
+		Name:      c.name,
+		Status:    health.StatusUnhealthy,
+		Error:     err,
+		Timestamp: start,
+		Metadata:  map[string]string{"statusCode": strconv.Itoa(resp.StatusCode)},
+	}
+
+	if c.snippetSize > 0 {
+		if body == nil {
+			body, _ = io.ReadAll(io.LimitReader(resp.Body, int64(c.snippetSize)))
+		}
+		if len(body) > c.snippetSize {
+			body = body[:c.snippetSize]
+		}
+		if len(body) > 0 {
+			hc.Metadata["body"] = strings.ToValidUTF8(string(body), "")
+		}
+	}
+
+	hc.Duration = time.Since(start)
+	return hc
+}
+
+func (c *Checker) acceptsStatus(code int) bool {
+	for _, r := range c.statuses {
+		if code >= r.lo && code <= r.hi {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Checker) needsBody() bool {
+	for _, a := range c.assertions {
+		if a.needsBody {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonPath returns the value at a dot-separated path in a JSON document.
+func jsonPath(body []byte, path string) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf("decode body: %w", err)
+	}
+
+	for _, key := range strings.Split(path, ".") {
+		switch node := v.(type) {
+		case map[string]any:
+			next, ok := node[key]
+			if !ok {
+				return nil, fmt.Errorf("expected %s in body", path)
+			}
+			v = next
+		case []any:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil, fmt.Errorf("expected %s in body", path)
+			}
+			v = node[i]
+		default:
+			return nil, fmt.Errorf("expected %s in body", path)
+		}
+	}
+	return v, nil
+}
+
+// jsonString returns strings as they are and other values as JSON.
+func jsonString(v any) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
@@ -2,8 +2,11 @@ package http_test
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"regexp"
+	"strings"
 	"testing"
 	"time"
 
@@ -129,3 +132,110 @@ func TestChecker_ContextCancelled(t *testing.T) {
 		t.Fatalf("expected unhealthy on cancelled context, got %s", result.Status)
 	}
 }
+
+func TestChecker_StatusRange(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	c := httpchecker.NewChecker("test", srv.URL, httpchecker.WithExpectedStatusRange(200, 299))
+	if result := c.Check(context.Background()); result.Status != health.StatusHealthy {
+		t.Fatalf("expected 202 to be in range, got %s (err: %v)", result.Status, result.Error)
+	}
+
+	c = httpchecker.NewChecker("test", srv.URL, httpchecker.WithExpectedStatuses(http.StatusOK, http.StatusNoContent))
+	result := c.Check(context.Background())
+	if result.Status != health.StatusUnhealthy || result.Metadata["statusCode"] != "202" {
+		t.Fatalf("expected 202 to be rejected, got %+v", result)
+	}
+}
+
+func TestChecker_RequestHeadersAndBody(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.Method != http.MethodPost || r.Header.Get("Authorization") != "Bearer token" || string(body) != `{"deep":true}` {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	c := httpchecker.NewChecker("test", srv.URL,
+		httpchecker.WithMethod(http.MethodPost),
+		httpchecker.WithHeader("Authorization", "Bearer token"),
+		httpchecker.WithHeader("Content-Type", "application/json"),
+		httpchecker.WithBody([]byte(`{"deep":true}`)),
+	)
+	if result := c.Check(context.Background()); result.Status != health.StatusHealthy {
+		t.Fatalf("expected healthy, got %s (err: %v)", result.Status, result.Error)
+	}
+}
+
+func TestChecker_BodyAssertions(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-Health", "partial")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"status":"down","checks":[{"name":"db","ok":false,"latency":12}]}`))
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name string
+		opt  httpchecker.Option
+		want string
+	}{
+		{"contains", httpchecker.WithBodyContains(`"status":"down"`), ""},
+		{"contains mismatch", httpchecker.WithBodyContains(`"status":"up"`), `expected body to contain`},
+		{"matches", httpchecker.WithBodyMatches(regexp.MustCompile(`"latency":\d+`)), ""},
+		{"matches mismatch", httpchecker.WithBodyMatches(regexp.MustCompile(`"status":"up"`)), `expected body to match`},
+		{"json equals", httpchecker.WithJSONPathEquals("checks.0.ok", "false"), ""},
+		{"json number", httpchecker.WithJSONPathEquals("checks.0.latency", "12"), ""},
+		{"json mismatch", httpchecker.WithJSONPathEquals("status", "up"), `expected status to be "up", got "down"`},
+		{"json exists", httpchecker.WithJSONPathExists("checks.0.name"), ""},
+		{"json missing", httpchecker.WithJSONPathExists("checks.1.name"), `expected checks.1.name in body`},
+		{"header", httpchecker.WithResponseHeader("x-health", "partial"), ""},
+		{"header mismatch", httpchecker.WithResponseHeader("X-Health", "ok"), `expected header X-Health to be "ok", got "partial"`},
+		{"header missing", httpchecker.WithResponseHeader("X-Version", ""), `expected header X-Version`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := httpchecker.NewChecker("test", srv.URL, tt.opt).Check(context.Background())
+			if tt.want == "" {
+				if result.Status != health.StatusHealthy {
+					t.Fatalf("expected healthy, got %s (err: %v)", result.Status, result.Error)
+				}
+				return
+			}
+			if result.Status != health.StatusUnhealthy || result.Error == nil || !strings.Contains(result.Error.Error(), tt.want) {
+				t.Fatalf("expected error containing %q, got %s (err: %v)", tt.want, result.Status, result.Error)
+			}
+			if !strings.HasPrefix(result.Metadata["body"], `{"status":"down"`) {
+				t.Fatalf("expected body snippet on failure, got %v", result.Metadata)
+			}
+		})
+	}
+}
+
+func TestChecker_BodySnippet(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(strings.Repeat("x", 1000)))
+	}))
+	defer srv.Close()
+
+	result := httpchecker.NewChecker("test", srv.URL, httpchecker.WithBodySnippet(16)).Check(context.Background())
+	if got := result.Metadata["body"]; got != strings.Repeat("x", 16) {
+		t.Fatalf("expected truncated snippet, got %q", got)
+	}
+	if result.Error.Error() != "expected status 200, got 503" {
+		t.Fatalf("unexpected error: %v", result.Error)
+	}
+
+	result = httpchecker.NewChecker("test", srv.URL, httpchecker.WithBodySnippet(0)).Check(context.Background())
+	if _, ok := result.Metadata["body"]; ok {
+		t.Fatal("expected no snippet when disabled")
+	}
+}
@@ -3,8 +3,14 @@ package redis
 import (
 	"bufio"
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
+	"io"
 	"net"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/schigh/health/v2"
@@ -12,15 +18,26 @@ import (
 
 const DefaultTimeout = 5 * time.Second
 
+// maxBulkSize bounds the bulk replies read, such as INFO's.
+const maxBulkSize = 1 << 20
+
 // Checker performs Redis health checks using the raw RESP protocol.
-// Zero external dependencies. Supports standalone Redis with optional
-// legacy AUTH. Does not support Redis Cluster or ACL-only (Redis 6+
-// without legacy password).
+// Zero external dependencies. Supports standalone Redis over TCP or TLS,
+// with legacy AUTH or Redis 6+ ACL users. Does not support Redis Cluster.
 type Checker struct {
 	name     string
 	addr     string
 	timeout  time.Duration
+	tls      *tls.Config
+	username string
 	password string
+	database int
+	info     bool
+	maxFrag  float64
+	latency  health.LatencyThresholds
+
+	mu       sync.Mutex
+	rejected int64 // rejected_connections at the last INFO, or -1
 }
 
 // Option is a functional option for configuring a Redis Checker.
@@ -31,29 +48,76 @@ func WithTimeout(d time.Duration) Option {
 	return func(c *Checker) { c.timeout = d }
 }
 
-// WithPassword sets the password for legacy AUTH before PING.
-// This sends AUTH <password> in cleartext over TCP. For production
-// Redis instances requiring authentication, use TLS.
+// WithLatencyThresholds reports a successful check as degraded when it takes
+// longer than warning, and as unhealthy when it takes longer than critical.
+// Zero disables either threshold.
+func WithLatencyThresholds(warning, critical time.Duration) Option {
+	return func(c *Checker) {
+		c.latency = health.LatencyThresholds{Warning: warning, Critical: critical}
+	}
+}
+
+// WithTLS connects over TLS. If cfg has no ServerName, the host part of the
+// address is used.
+func WithTLS(cfg *tls.Config) Option {
+	return func(c *Checker) { c.tls = cfg }
+}
+
+// WithPassword sets the password for AUTH before PING.
+// Without WithTLS, this sends AUTH <password> in cleartext over TCP. For
+// production Redis instances requiring authentication, use TLS.
 func WithPassword(password string) Option {
 	return func(c *Checker) { c.password = password }
 }
 
+// WithUsername sets the Redis 6+ ACL user, sending AUTH <username>
+// <password> instead of legacy AUTH. Use it with WithPassword.
+func WithUsername(username string) Option {
+	return func(c *Checker) { c.username = username }
+}
+
+// WithDatabase selects the logical database with SELECT before PING, which
+// checks that it exists and that the user may access it.
+func WithDatabase(db int) Option {
+	return func(c *Checker) { c.database = db }
+}
+
+// WithInfo runs INFO after PING and reports degraded while the server is
+// loading its dataset, while a replica's link to its master is down, and
+// when rejected_connections has grown since the previous check. The role,
+// version and counters read are recorded in Metadata.
+func WithInfo() Option {
+	return func(c *Checker) { c.info = true }
+}
+
+// WithMaxFragmentation reports degraded when INFO's mem_fragmentation_ratio
+// is above ratio. It implies WithInfo. The ratio is unreliable on instances
+// using little memory, so set it with care.
+func WithMaxFragmentation(ratio float64) Option {
+	return func(c *Checker) {
+		c.info = true
+		c.maxFrag = ratio
+	}
+}
+
 // NewChecker returns a Redis health checker for the given address (host:port).
 func NewChecker(name, addr string, opts ...Option) *Checker {
-	c := &Checker{name: name, addr: addr, timeout: DefaultTimeout}
+	c := &Checker{name: name, addr: addr, timeout: DefaultTimeout, rejected: -1}
 	for _, o := range opts {
 		o(c)
 	}
 	return c
 }
 
+// Check satisfies health.Checker.
 func (c *Checker) Check(ctx context.Context) *health.CheckResult {
-	start := time.Now()
+	return c.latency.Apply(c.check(ctx))
+}
 
-	var d net.Dialer
-	d.Timeout = c.timeout
+func (c *Checker) check(ctx context.Context) *health.CheckResult {
+	start := time.Now()
 
-	conn, err := d.DialContext(ctx, "tcp", c.addr)
+	conn, err := c.dial(ctx)
 	if err != nil {
 		return unhealthy(c.name, start, fmt.Errorf("dial %s: %w", c.addr, err))
 	}
@@ -70,30 +134,74 @@ func (c *Checker) Check(ctx context.Context) *health.CheckResult {
 		}
 	}
 
+	if c.database != 0 {
+		if err := c.selectDB(conn, reader); err != nil {
+			return unhealthy(c.name, start, err)
+		}
+	}
+
 	if err := c.ping(conn, reader); err != nil {
 		return unhealthy(c.name, start, err)
 	}
 
-	return &health.CheckResult{
+	hc := &health.CheckResult{
 		Name:      c.name,
 		Status:    health.StatusHealthy,
-		Duration:  time.Since(start),
 		Timestamp: start,
 	}
+	if c.info {
+		fields, err := c.readInfo(conn, reader)
+		if err != nil {
+			return unhealthy(c.name, start, err)
+		}
+		hc.Metadata, hc.Error = c.inspect(fields)
+		if hc.Error != nil {
+			hc.Status = health.StatusDegraded
+		}
+	}
+	hc.Duration = time.Since(start)
+	return hc
+}
+
+func (c *Checker) dial(ctx context.Context) (net.Conn, error) {
+	d := net.Dialer{Timeout: c.timeout}
+	if c.tls == nil {
+		return d.DialContext(ctx, "tcp", c.addr)
+	}
+	td := tls.Dialer{NetDialer: &d, Config: c.tls}
+	return td.DialContext(ctx, "tcp", c.addr)
 }
 
 // authenticate sends AUTH and validates the response.
 func (c *Checker) authenticate(conn net.Conn, reader *bufio.Reader) error {
-	_, err := fmt.Fprintf(conn, "*2\r\n$4\r\nAUTH\r\n$%d\r\n%s\r\n", len(c.password), c.password)
-	if err != nil {
+	args := []string{"AUTH", c.password}
+	if c.username != "" {
+		args = []string{"AUTH", c.username, c.password}
+	}
+	if err := writeCommand(conn, args...); err != nil {
 		return fmt.Errorf("write AUTH: %w", err)
 	}
-	line, err := reader.ReadString('\n')
+	kind, reply, err := readReply(reader)
 	if err != nil {
 		return fmt.Errorf("read AUTH response: %w", err)
 	}
-	if len(line) < 3 || line[0] != '+' {
-		return fmt.Errorf("AUTH failed: %s", line)
+	if kind != '+' {
+		return fmt.Errorf("AUTH failed: %s", reply)
+	}
+	return nil
+}
+
+// selectDB sends SELECT and validates the response.
+func (c *Checker) selectDB(conn net.Conn, reader *bufio.Reader) error {
+	if err := writeCommand(conn, "SELECT", strconv.Itoa(c.database)); err != nil {
+		return fmt.Errorf("write SELECT: %w", err)
+	}
+	kind, reply, err := readReply(reader)
+	if err != nil {
+		return fmt.Errorf("read SELECT response: %w", err)
+	}
+	if kind != '+' {
+		return fmt.Errorf("SELECT %d failed: %s", c.database, reply)
 	}
 	return nil
 }
@@ -114,6 +222,117 @@ func (c *Checker) ping(conn net.Conn, reader *bufio.Reader) error {
 	return nil
 }
 
+// readInfo sends INFO and parses its "field:value" lines.
+func (c *Checker) readInfo(conn net.Conn, reader *bufio.Reader) (map[string]string, error) {
+	if err := writeCommand(conn, "INFO"); err != nil {
+		return nil, fmt.Errorf("write INFO: %w", err)
+	}
+	kind, reply, err := readReply(reader)
+	if err != nil {
+		return nil, fmt.Errorf("read INFO response: %w", err)
+	}
+	if kind != '$' {
+		return nil, fmt.Errorf("INFO failed: %s", reply)
+	}
+
+	fields := make(map[string]string)
+	for _, line := range strings.Split(reply, "\r\n") {
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		if k, v, ok := strings.Cut(line, ":"); ok {
+			fields[k] = v
+		}
+	}
+	return fields, nil
+}
+
+// inspect records the INFO fields of interest and returns why the server
+// is degraded, if it is.
+func (c *Checker) inspect(fields map[string]string) (map[string]string, error) {
+	md := make(map[string]string)
+	for key, field := range map[string]string{
+		"role":                  "role",
+		"version":               "redis_version",
+		"loading":               "loading",
+		"masterLinkStatus":      "master_link_status",
+		"memFragmentationRatio": "mem_fragmentation_ratio",
+		"rejectedConnections":   "rejected_connections",
+	} {
+		if v, ok := fields[field]; ok {
+			md[key] = v
+		}
+	}
+
+	var errs []error
+	if fields["loading"] == "1" {
+		errs = append(errs, errors.New("loading dataset"))
+	}
+	if fields["role"] == "slave" && fields["master_link_status"] != "up" {
+		errs = append(errs, errors.New("replication link to master is down"))
+	}
+	if c.maxFrag > 0 {
+		if ratio, err := strconv.ParseFloat(fields["mem_fragmentation_ratio"], 64); err == nil && ratio > c.maxFrag {
+			errs = append(errs, fmt.Errorf("memory fragmentation ratio %.2f above %.2f", ratio, c.maxFrag))
+		}
+	}
+	if n, err := strconv.ParseInt(fields["rejected_connections"], 10, 64); err == nil {
+		c.mu.Lock()
+		prev := c.rejected
+		c.rejected = n
+		c.mu.Unlock()
+		if prev >= 0 && n > prev {
+			errs = append(errs, fmt.Errorf("rejected %d connections since the last check", n-prev))
+		}
+	}
+	return md, errors.Join(errs...)
+}
+
+// writeCommand writes a command as a RESP array of bulk strings.
+func writeCommand(w io.Writer, args ...string) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// readReply reads a simple string, error, integer or bulk string reply,
+// returning its type byte and payload.
+func readReply(reader *bufio.Reader) (byte, string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return 0, "", err
+	}
+	line = strings.TrimRight(line, "\r\n")
+	if line == "" {
+		return 0, "", errors.New("empty response")
+	}
+
+	kind, payload := line[0], line[1:]
+	switch kind {
+	case '+', '-', ':':
+		return kind, payload, nil
+	case '$':
+		n, err := strconv.Atoi(payload)
+		if err != nil || n > maxBulkSize {
+			return 0, "", fmt.Errorf("malformed bulk length: %s", line)
+		}
+		if n < 0 {
+			return kind, "", nil
+		}
+		buf := make([]byte, n+2)
+		if _, err := io.ReadFull(reader, buf); err != nil {
+			return 0, "", err
+		}
+		return kind, string(buf[:n]), nil
+	default:
+		return 0, "", fmt.Errorf("unexpected response: %s", line)
+	}
+}
+
 func unhealthy(name string, start time.Time, err error) *health.CheckResult {
 	return &health.CheckResult{
 		Name:      name,
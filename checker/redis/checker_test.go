@@ -2,8 +2,12 @@ package redis_test
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"net"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -112,3 +116,203 @@ func TestChecker_MalformedResponse(t *testing.T) {
 		t.Fatalf("expected unhealthy on malformed response, got %s", result.Status)
 	}
 }
+
+// serveCommands accepts one connection and answers each command read with
+// the next reply, recording the commands.
+func serveCommands(t *testing.T, ln net.Listener, replies ...string) <-chan string {
+	t.Helper()
+	commands := make(chan string, len(replies))
+	go func() {
+		defer close(commands)
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		buf := make([]byte, 256)
+		for _, reply := range replies {
+			n, err := conn.Read(buf)
+			if err != nil {
+				return
+			}
+			commands <- string(buf[:n])
+			fmt.Fprint(conn, reply)
+		}
+	}()
+	return commands
+}
+
+func listen(t *testing.T) net.Listener {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	return ln
+}
+
+func bulk(s string) string {
+	return fmt.Sprintf("$%d\r\n%s\r\n", len(s), s)
+}
+
+func TestChecker_ACLAuthAndSelect(t *testing.T) {
+	ln := listen(t)
+	commands := serveCommands(t, ln, "+OK\r\n", "+OK\r\n", "+PONG\r\n")
+
+	c := redis.NewChecker("test", ln.Addr().String(),
+		redis.WithUsername("health"),
+		redis.WithPassword("secret"),
+		redis.WithDatabase(3),
+		redis.WithTimeout(time.Second),
+	)
+	result := c.Check(context.Background())
+	if result.Status != health.StatusHealthy {
+		t.Fatalf("expected healthy, got %s (err: %v)", result.Status, result.Error)
+	}
+
+	for _, want := range []string{
+		"*3\r\n$4\r\nAUTH\r\n$6\r\nhealth\r\n$6\r\nsecret\r\n",
+		"*2\r\n$6\r\nSELECT\r\n$1\r\n3\r\n",
+		"*1\r\n$4\r\nPING\r\n",
+	} {
+		if got := <-commands; got != want {
+			t.Fatalf("expected command %q, got %q", want, got)
+		}
+	}
+}
+
+func TestChecker_SelectFailure(t *testing.T) {
+	ln := listen(t)
+	serveCommands(t, ln, "-ERR DB index is out of range\r\n")
+
+	c := redis.NewChecker("test", ln.Addr().String(), redis.WithDatabase(99), redis.WithTimeout(time.Second))
+	result := c.Check(context.Background())
+	if result.Status != health.StatusUnhealthy || result.Error == nil || !strings.Contains(result.Error.Error(), "out of range") {
+		t.Fatalf("expected unhealthy on SELECT failure, got %s (err: %v)", result.Status, result.Error)
+	}
+}
+
+func TestChecker_TLS(t *testing.T) {
+	// borrow httptest's certificate for a TLS listener
+	srv := httptest.NewTLSServer(nil)
+	cfg := srv.TLS.Clone()
+	roots := x509.NewCertPool()
+	roots.AddCert(srv.Certificate())
+	srv.Close()
+
+	ln, err := tls.Listen("tcp", "127.0.0.1:0", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	serveCommands(t, ln, "+PONG\r\n")
+
+	c := redis.NewChecker("test", ln.Addr().String(),
+		redis.WithTLS(&tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12}),
+		redis.WithTimeout(time.Second),
+	)
+	result := c.Check(context.Background())
+	if result.Status != health.StatusHealthy {
+		t.Fatalf("expected healthy over TLS, got %s (err: %v)", result.Status, result.Error)
+	}
+}
+
+func TestChecker_Info(t *testing.T) {
+	tests := []struct {
+		name   string
+		info   string
+		want   health.Status
+		errMsg string
+	}{
+		{
+			name: "healthy master",
+			info: "# Server\r\nredis_version:7.2.4\r\n# Replication\r\nrole:master\r\n",
+			want: health.StatusHealthy,
+		},
+		{
+			name:   "loading",
+			info:   "# Persistence\r\nloading:1\r\n# Replication\r\nrole:master\r\n",
+			want:   health.StatusDegraded,
+			errMsg: "loading dataset",
+		},
+		{
+			name:   "replica link down",
+			info:   "# Replication\r\nrole:slave\r\nmaster_link_status:down\r\n",
+			want:   health.StatusDegraded,
+			errMsg: "replication link to master is down",
+		},
+		{
+			name:   "fragmented",
+			info:   "# Memory\r\nmem_fragmentation_ratio:2.31\r\n",
+			want:   health.StatusDegraded,
+			errMsg: "memory fragmentation ratio 2.31 above 1.50",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ln := listen(t)
+			serveCommands(t, ln, "+PONG\r\n", bulk(tt.info))
+
+			c := redis.NewChecker("test", ln.Addr().String(),
+				redis.WithMaxFragmentation(1.5),
+				redis.WithTimeout(time.Second),
+			)
+			result := c.Check(context.Background())
+			if result.Status != tt.want {
+				t.Fatalf("expected %s, got %s (err: %v)", tt.want, result.Status, result.Error)
+			}
+			if tt.errMsg == "" && result.Error != nil {
+				t.Fatalf("expected no error, got %v", result.Error)
+			}
+			if tt.errMsg != "" && (result.Error == nil || result.Error.Error() != tt.errMsg) {
+				t.Fatalf("expected error %q, got %v", tt.errMsg, result.Error)
+			}
+		})
+	}
+}
+
+func TestChecker_InfoMetadata(t *testing.T) {
+	ln := listen(t)
+	serveCommands(t, ln, "+PONG\r\n", bulk("redis_version:7.2.4\r\nrole:slave\r\nmaster_link_status:up\r\n"))
+
+	c := redis.NewChecker("test", ln.Addr().String(), redis.WithInfo(), redis.WithTimeout(time.Second))
+	result := c.Check(context.Background())
+	if result.Status != health.StatusHealthy {
+		t.Fatalf("expected healthy, got %s (err: %v)", result.Status, result.Error)
+	}
+	if result.Metadata["role"] != "slave" || result.Metadata["version"] != "7.2.4" || result.Metadata["masterLinkStatus"] != "up" {
+		t.Fatalf("expected INFO fields in metadata, got %v", result.Metadata)
+	}
+}
+
+func TestChecker_InfoRejectedConnections(t *testing.T) {
+	ln := listen(t)
+	c := redis.NewChecker("test", ln.Addr().String(), redis.WithInfo(), redis.WithTimeout(time.Second))
+
+	for i, tt := range []struct {
+		rejected string
+		want     health.Status
+	}{
+		{"4", health.StatusHealthy},
+		{"4", health.StatusHealthy},
+		{"9", health.StatusDegraded},
+	} {
+		serveCommands(t, ln, "+PONG\r\n", bulk("rejected_connections:"+tt.rejected+"\r\n"))
+		result := c.Check(context.Background())
+		if result.Status != tt.want {
+			t.Fatalf("check %d: expected %s, got %s (err: %v)", i, tt.want, result.Status, result.Error)
+		}
+	}
+}
+
+func TestChecker_InfoError(t *testing.T) {
+	ln := listen(t)
+	serveCommands(t, ln, "+PONG\r\n", "-NOPERM this user has no permissions to run the 'info' command\r\n")
+
+	c := redis.NewChecker("test", ln.Addr().String(), redis.WithInfo(), redis.WithTimeout(time.Second))
+	result := c.Check(context.Background())
+	if result.Status != health.StatusUnhealthy || result.Error == nil || !strings.Contains(result.Error.Error(), "NOPERM") {
+		t.Fatalf("expected unhealthy on INFO error, got %s (err: %v)", result.Status, result.Error)
+	}
+}
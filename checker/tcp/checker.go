@@ -16,6 +16,7 @@ type Checker struct {
 	name    string
 	addr    string
 	timeout time.Duration
+	latency health.LatencyThresholds
 }
 
 // Option is a functional option for configuring a TCP Checker.
@@ -26,6 +27,15 @@ func WithTimeout(d time.Duration) Option {
 	return func(c *Checker) { c.timeout = d }
 }
 
+// WithLatencyThresholds reports a successful check as degraded when it takes
+// longer than warning, and as unhealthy when it takes longer than critical.
+// Zero disables either threshold.
+func WithLatencyThresholds(warning, critical time.Duration) Option {
+	return func(c *Checker) {
+		c.latency = health.LatencyThresholds{Warning: warning, Critical: critical}
+	}
+}
+
 // NewChecker returns a TCP health checker for the given address (host:port).
 func NewChecker(name, addr string, opts ...Option) *Checker {
 	c := &Checker{name: name, addr: addr, timeout: DefaultTimeout}
@@ -35,7 +45,12 @@ func NewChecker(name, addr string, opts ...Option) *Checker {
 	return c
 }
 
+// Check satisfies health.Checker.
 func (c *Checker) Check(ctx context.Context) *health.CheckResult {
+	return c.latency.Apply(c.check(ctx))
+}
+
+func (c *Checker) check(ctx context.Context) *health.CheckResult {
 	start := time.Now()
 
 	var d net.Dialer
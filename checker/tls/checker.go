@@ -0,0 +1,174 @@
+package tls
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/schigh/health/v2"
+)
+
+const (
+	DefaultTimeout = 5 * time.Second
+
+	// DefaultExpiryWarning is how long before a certificate expires that
+	// the check starts reporting degraded.
+	DefaultExpiryWarning = 30 * 24 * time.Hour
+)
+
+// Checker performs a TLS handshake and checks the server's certificate chain.
+// A chain that does not verify, or that contains an expired certificate, is
+// unhealthy; one with a certificate that expires within the warning window is
+// degraded. The leaf certificate's subject, issuer and expiry are recorded in
+// Metadata under "subject", "issuer" and "notAfter".
+type Checker struct {
+	name        string
+	addr        string
+	serverName  string
+	rootCAs     *x509.CertPool
+	clientCerts []tls.Certificate
+	timeout     time.Duration
+	warning     time.Duration
+	latency     health.LatencyThresholds
+}
+
+// Option is a functional option for configuring a TLS Checker.
+type Option func(*Checker)
+
+// WithTimeout sets the timeout for the dial and handshake.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Checker) { c.timeout = d }
+}
+
+// WithLatencyThresholds reports a successful check as degraded when it takes
+// longer than warning, and as unhealthy when it takes longer than critical.
+// Zero disables either threshold.
+func WithLatencyThresholds(warning, critical time.Duration) Option {
+	return func(c *Checker) {
+		c.latency = health.LatencyThresholds{Warning: warning, Critical: critical}
+	}
+}
+
+// WithServerName sets the name sent for SNI and verified against the
+// certificate. Default is the host part of the address.
+func WithServerName(name string) Option {
+	return func(c *Checker) { c.serverName = name }
+}
+
+// WithRootCAs sets the pool used to verify the chain. Default is the system
+// pool.
+func WithRootCAs(pool *x509.CertPool) Option {
+	return func(c *Checker) { c.rootCAs = pool }
+}
+
+// WithClientCertificates sets the certificates presented to servers that
+// require client authentication. With TLS 1.3 a server rejects a client
+// certificate after the handshake completes, so the rejection is not
+// reported by the check.
+func WithClientCertificates(certs ...tls.Certificate) Option {
+	return func(c *Checker) { c.clientCerts = append(c.clientCerts, certs...) }
+}
+
+// WithExpiryWarning sets how long before a certificate in the chain expires
+// that the check reports degraded. Zero disables it. Default is
+// DefaultExpiryWarning.
+func WithExpiryWarning(d time.Duration) Option {
+	return func(c *Checker) { c.warning = d }
+}
+
+// NewChecker returns a TLS health checker for the given address (host:port).
+func NewChecker(name, addr string, opts ...Option) *Checker {
+	c := &Checker{name: name, addr: addr, timeout: DefaultTimeout, warning: DefaultExpiryWarning}
+	for _, o := range opts {
+		o(c)
+	}
+	if c.serverName == "" {
+		if host, _, err := net.SplitHostPort(addr); err == nil {
+			c.serverName = host
+		}
+	}
+	return c
+}
+
+// Check satisfies health.Checker.
+func (c *Checker) Check(ctx context.Context) *health.CheckResult {
+	return c.latency.Apply(c.check(ctx))
+}
+
+func (c *Checker) check(ctx context.Context) *health.CheckResult {
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	// The chain is verified below rather than during the handshake, so that
+	// an expired or otherwise invalid certificate is still described in the
+	// result. No application data is exchanged on the connection.
+	d := tls.Dialer{Config: &tls.Config{
+		ServerName:         c.serverName,
+		Certificates:       c.clientCerts,
+		InsecureSkipVerify: true, //nolint:gosec // verified in check
+		MinVersion:         tls.VersionTLS12,
+	}}
+	conn, err := d.DialContext(ctx, "tcp", c.addr)
+	if err != nil {
+		return c.result(start, health.StatusUnhealthy, nil, fmt.Errorf("handshake %s: %w", c.addr, err))
+	}
+	certs := conn.(*tls.Conn).ConnectionState().PeerCertificates
+	_ = conn.Close()
+
+	if len(certs) == 0 {
+		return c.result(start, health.StatusUnhealthy, nil, fmt.Errorf("%s presented no certificate", c.addr))
+	}
+	leaf := certs[0]
+	md := map[string]string{
+		"subject":  leaf.Subject.String(),
+		"issuer":   leaf.Issuer.String(),
+		"notAfter": leaf.NotAfter.UTC().Format(time.RFC3339),
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	chains, err := leaf.Verify(x509.VerifyOptions{
+		DNSName:       c.serverName,
+		Roots:         c.rootCAs,
+		Intermediates: intermediates,
+		CurrentTime:   start,
+	})
+	if err != nil {
+		return c.result(start, health.StatusUnhealthy, md, fmt.Errorf("verify certificate: %w", err))
+	}
+
+	// the certificate in the verified chain that expires first
+	first := leaf
+	for _, cert := range chains[0][1:] {
+		if cert.NotAfter.Before(first.NotAfter) {
+			first = cert
+		}
+	}
+	if first != leaf {
+		md["chainSubject"] = first.Subject.String()
+		md["chainNotAfter"] = first.NotAfter.UTC().Format(time.RFC3339)
+	}
+
+	if remaining := first.NotAfter.Sub(start); c.warning > 0 && remaining < c.warning {
+		return c.result(start, health.StatusDegraded, md,
+			fmt.Errorf("certificate %s expires in %s", first.Subject, remaining.Round(time.Minute)))
+	}
+	return c.result(start, health.StatusHealthy, md, nil)
+}
+
+func (c *Checker) result(start time.Time, status health.Status, md map[string]string, err error) *health.CheckResult {
+	return &health.CheckResult{
+		Name:      c.name,
+		Status:    status,
+		Error:     err,
+		Duration:  time.Since(start),
+		Metadata:  md,
+		Timestamp: start,
+	}
+}
@@ -0,0 +1,176 @@
+package tls_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/schigh/health/v2"
+	tlschecker "github.com/schigh/health/v2/checker/tls"
+)
+
+// newCert returns a self-signed certificate for 127.0.0.1 and localhost.
+func newCert(t *testing.T, notBefore, notAfter time.Time) tls.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "localhost", Organization: []string{"Test"}},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		DNSNames:              []string{"localhost"},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}
+}
+
+func serveTLS(t *testing.T, cfg *tls.Config) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewUnstartedServer(http.NotFoundHandler())
+	srv.TLS = cfg
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func pool(certs ...*x509.Certificate) *x509.CertPool {
+	p := x509.NewCertPool()
+	for _, cert := range certs {
+		p.AddCert(cert)
+	}
+	return p
+}
+
+func TestChecker_Healthy(t *testing.T) {
+	srv := httptest.NewTLSServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	c := tlschecker.NewChecker("test", srv.Listener.Addr().String(),
+		tlschecker.WithRootCAs(pool(srv.Certificate())))
+	result := c.Check(context.Background())
+
+	if result.Status != health.StatusHealthy {
+		t.Fatalf("expected healthy, got %s (err: %v)", result.Status, result.Error)
+	}
+	if result.Metadata["subject"] == "" || result.Metadata["issuer"] == "" {
+		t.Fatalf("expected subject and issuer in metadata, got %v", result.Metadata)
+	}
+	if want := srv.Certificate().NotAfter.UTC().Format(time.RFC3339); result.Metadata["notAfter"] != want {
+		t.Fatalf("expected notAfter %s, got %v", want, result.Metadata)
+	}
+}
+
+func TestChecker_Untrusted(t *testing.T) {
+	srv := httptest.NewTLSServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	result := tlschecker.NewChecker("test", srv.Listener.Addr().String()).Check(context.Background())
+	if result.Status != health.StatusUnhealthy {
+		t.Fatalf("expected unhealthy for untrusted chain, got %s", result.Status)
+	}
+	if result.Metadata["subject"] == "" {
+		t.Fatalf("expected subject of the untrusted certificate in metadata, got %v", result.Metadata)
+	}
+}
+
+func TestChecker_ServerName(t *testing.T) {
+	srv := httptest.NewTLSServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	c := tlschecker.NewChecker("test", srv.Listener.Addr().String(),
+		tlschecker.WithRootCAs(pool(srv.Certificate())),
+		tlschecker.WithServerName("example.org"))
+	result := c.Check(context.Background())
+	if result.Status != health.StatusUnhealthy || result.Error == nil || !strings.Contains(result.Error.Error(), "example.org") {
+		t.Fatalf("expected unhealthy for mismatched server name, got %s (err: %v)", result.Status, result.Error)
+	}
+}
+
+func TestChecker_Expiry(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name     string
+		notAfter time.Time
+		want     health.Status
+	}{
+		{"valid", now.Add(90 * 24 * time.Hour), health.StatusHealthy},
+		{"expiring", now.Add(7 * 24 * time.Hour), health.StatusDegraded},
+		{"expired", now.Add(-time.Hour), health.StatusUnhealthy},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cert := newCert(t, now.Add(-48*time.Hour), tt.notAfter)
+			srv := serveTLS(t, &tls.Config{Certificates: []tls.Certificate{cert}})
+
+			c := tlschecker.NewChecker("test", srv.Listener.Addr().String(),
+				tlschecker.WithRootCAs(pool(cert.Leaf)))
+			result := c.Check(context.Background())
+			if result.Status != tt.want {
+				t.Fatalf("expected %s, got %s (err: %v)", tt.want, result.Status, result.Error)
+			}
+			if want := tt.notAfter.UTC().Format(time.RFC3339); result.Metadata["notAfter"] != want {
+				t.Fatalf("expected notAfter %s, got %v", want, result.Metadata)
+			}
+		})
+	}
+}
+
+func TestChecker_ClientCertificates(t *testing.T) {
+	now := time.Now()
+	serverCert := newCert(t, now.Add(-time.Hour), now.Add(90*24*time.Hour))
+	clientCert := newCert(t, now.Add(-time.Hour), now.Add(90*24*time.Hour))
+	// with TLS 1.3 the client certificate is rejected after the handshake
+	srv := serveTLS(t, &tls.Config{
+		MaxVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{serverCert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    pool(clientCert.Leaf),
+	})
+
+	c := tlschecker.NewChecker("test", srv.Listener.Addr().String(),
+		tlschecker.WithRootCAs(pool(serverCert.Leaf)))
+	if result := c.Check(context.Background()); result.Status != health.StatusUnhealthy {
+		t.Fatalf("expected unhealthy without a client certificate, got %s", result.Status)
+	}
+
+	c = tlschecker.NewChecker("test", srv.Listener.Addr().String(),
+		tlschecker.WithRootCAs(pool(serverCert.Leaf)),
+		tlschecker.WithClientCertificates(clientCert))
+	if result := c.Check(context.Background()); result.Status != health.StatusHealthy {
+		t.Fatalf("expected healthy with a client certificate, got %s (err: %v)", result.Status, result.Error)
+	}
+}
+
+func TestChecker_Unreachable(t *testing.T) {
+	c := tlschecker.NewChecker("test", "127.0.0.1:1", tlschecker.WithTimeout(100*time.Millisecond))
+	result := c.Check(context.Background())
+	if result.Status != health.StatusUnhealthy || result.Error == nil {
+		t.Fatalf("expected unhealthy with error, got %+v", result)
+	}
+}
@@ -0,0 +1,210 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// All returns a Checker that passes only if every child passes. Children run
+// concurrently. The combined result is degraded if any child is degraded.
+func All(checks ...Checker) Checker {
+	return Quorum(len(checks), checks...)
+}
+
+// Any returns a Checker that passes if at least one child passes. Children
+// run concurrently. The combined result is degraded while some children fail.
+func Any(checks ...Checker) Checker {
+	return Quorum(1, checks...)
+}
+
+// Quorum returns a Checker that passes if at least n children pass. Children
+// run concurrently. The combined result is healthy only if every child is
+// healthy, degraded if the quorum is met otherwise, and unhealthy if it is
+// not. Its Metadata records the passing count under "passing", such as
+// "2 of 3".
+func Quorum(n int, checks ...Checker) Checker {
+	return CheckerFunc(func(ctx context.Context) *CheckResult {
+		start := time.Now()
+		results := make([]*CheckResult, len(checks))
+
+		var wg sync.WaitGroup
+		for i := range checks {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				// a panic here would bypass the manager's recovery, which
+				// only covers the goroutine that called Check
+				defer func() {
+					if r := recover(); r != nil {
+						results[i] = &CheckResult{
+							Status:     StatusUnhealthy,
+							Error:      fmt.Errorf("checker panicked: %v", r),
+							ErrorSince: time.Now(),
+							Timestamp:  time.Now(),
+						}
+					}
+				}()
+				results[i] = checks[i].Check(ctx)
+			}(i)
+		}
+		wg.Wait()
+
+		out := combineResults(start, results)
+		passing, degraded := 0, false
+		for _, r := range results {
+			if passed(r) {
+				passing++
+				degraded = degraded || r.Status == StatusDegraded
+			}
+		}
+		out.Metadata["passing"] = fmt.Sprintf("%d of %d", passing, len(checks))
+
+		switch {
+		case passing < n:
+			out.Status = StatusUnhealthy
+		case passing < len(checks) || degraded:
+			out.Status = StatusDegraded
+		default:
+			out.Status = StatusHealthy
+		}
+		return out
+	})
+}
+
+// Fallback returns a Checker that runs its children in order until one
+// passes. If the first child passes, its status is used; if a later one does,
+// the combined result is degraded. If none passes, it is unhealthy. Its
+// Metadata records the child that passed under "passed".
+func Fallback(checks ...Checker) Checker {
+	return CheckerFunc(func(ctx context.Context) *CheckResult {
+		start := time.Now()
+		results := make([]*CheckResult, 0, len(checks))
+
+		for i := range checks {
+			r := checks[i].Check(ctx)
+			results = append(results, r)
+			if !passed(r) {
+				continue
+			}
+
+			out := combineResults(start, results)
+			out.Metadata["passed"] = childName(i, r)
+			out.Status = r.Status
+			if i > 0 {
+				out.Status = StatusDegraded
+			}
+			return out
+		}
+
+		out := combineResults(start, results)
+		out.Status = StatusUnhealthy
+		return out
+	})
+}
+
+// DegradeOnFailure returns a Checker that reports an unhealthy result from
+// check as degraded, keeping its error, so that the failure is visible
+// without affecting liveness or readiness.
+func DegradeOnFailure(check Checker) Checker {
+	return CheckerFunc(func(ctx context.Context) *CheckResult {
+		r := check.Check(ctx)
+		if r == nil {
+			r = &CheckResult{Status: StatusUnhealthy, Error: errors.New("no result"), Timestamp: time.Now()}
+		}
+		if r.Status != StatusUnhealthy {
+			return r
+		}
+		out := *r
+		out.Status = StatusDegraded
+		return &out
+	})
+}
+
+// Invert returns a Checker that passes when check fails and fails when it
+// passes, for conditions that must not hold, such as a maintenance flag
+// being set. Skipped results are returned as they are.
+func Invert(check Checker) Checker {
+	return CheckerFunc(func(ctx context.Context) *CheckResult {
+		r := check.Check(ctx)
+		if r == nil {
+			r = &CheckResult{Status: StatusUnhealthy, Timestamp: time.Now()}
+		}
+		out := *r
+		switch {
+		case r.Status == StatusSkipped:
+			return r
+		case passed(r):
+			out.Status = StatusUnhealthy
+			out.Error = errors.New("inverted check passed")
+			if r.Name != "" {
+				out.Error = fmt.Errorf("%s passed", r.Name)
+			}
+			out.ErrorSince = r.Timestamp
+		default:
+			out.Status = StatusHealthy
+			out.Error = nil
+			out.ErrorSince = time.Time{}
+		}
+		return &out
+	})
+}
+
+// passed reports whether a child result counts as passing. Skipped and
+// missing results do not.
+func passed(r *CheckResult) bool {
+	return r != nil && (r.Status == StatusHealthy || r.Status == StatusDegraded)
+}
+
+// childName identifies a child result in errors and metadata by its Name,
+// or by its position if it has none.
+func childName(i int, r *CheckResult) string {
+	if r != nil && r.Name != "" {
+		return r.Name
+	}
+	return fmt.Sprintf("check %d", i+1)
+}
+
+// combineResults merges child results into one without a status: Duration is
+// the time since start, Metadata holds each child's entries prefixed with
+// its name, and Error joins the errors of the children that did not pass,
+// each prefixed with the child's name.
+func combineResults(start time.Time, results []*CheckResult) *CheckResult {
+	now := time.Now()
+	out := &CheckResult{
+		Duration:  now.Sub(start),
+		Metadata:  make(map[string]string),
+		Timestamp: now,
+	}
+
+	var errs []error
+	for i, r := range results {
+		name := childName(i, r)
+		if r == nil {
+			errs = append(errs, fmt.Errorf("%s: no result", name))
+			continue
+		}
+		for k, v := range r.Metadata {
+			out.Metadata[name+"."+k] = v
+		}
+		if passed(r) {
+			continue
+		}
+		err := r.Error
+		if err == nil {
+			err = errors.New(r.Status.String())
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		if !r.ErrorSince.IsZero() && (out.ErrorSince.IsZero() || r.ErrorSince.Before(out.ErrorSince)) {
+			out.ErrorSince = r.ErrorSince
+		}
+	}
+
+	out.Error = errors.Join(errs...)
+	if out.Error != nil && out.ErrorSince.IsZero() {
+		out.ErrorSince = now
+	}
+	return out
+}
@@ -0,0 +1,159 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/schigh/health/v2"
+)
+
+func fixed(name string, status health.Status, err error) health.Checker {
+	return health.CheckerFunc(func(context.Context) *health.CheckResult {
+		return &health.CheckResult{
+			Name:      name,
+			Status:    status,
+			Error:     err,
+			Metadata:  map[string]string{"addr": name + ":5432"},
+			Timestamp: time.Now(),
+		}
+	})
+}
+
+func TestCombinators(t *testing.T) {
+	var (
+		up       = fixed("primary", health.StatusHealthy, nil)
+		replica  = fixed("replica", health.StatusHealthy, nil)
+		slow     = fixed("slow", health.StatusDegraded, nil)
+		down     = fixed("down", health.StatusUnhealthy, errors.New("connection refused"))
+		unnamed  = fixed("", health.StatusUnhealthy, nil)
+		ctx      = context.Background()
+		statusOf = func(c health.Checker) health.Status { return c.Check(ctx).Status }
+	)
+
+	tests := []struct {
+		name  string
+		check health.Checker
+		want  health.Status
+	}{
+		{"all pass", health.All(up, replica), health.StatusHealthy},
+		{"all with degraded", health.All(up, slow), health.StatusDegraded},
+		{"all with failure", health.All(up, down), health.StatusUnhealthy},
+		{"any with failure", health.Any(down, up), health.StatusDegraded},
+		{"any all failing", health.Any(down, unnamed), health.StatusUnhealthy},
+		{"quorum met", health.Quorum(2, up, replica, down), health.StatusDegraded},
+		{"quorum missed", health.Quorum(2, up, down, unnamed), health.StatusUnhealthy},
+		{"fallback primary", health.Fallback(up, down), health.StatusHealthy},
+		{"fallback secondary", health.Fallback(down, replica), health.StatusDegraded},
+		{"fallback none", health.Fallback(down, unnamed), health.StatusUnhealthy},
+		{"degrade on failure", health.DegradeOnFailure(down), health.StatusDegraded},
+		{"degrade passes through", health.DegradeOnFailure(up), health.StatusHealthy},
+		{"invert failing", health.Invert(down), health.StatusHealthy},
+		{"invert passing", health.Invert(up), health.StatusUnhealthy},
+		{"nested", health.All(up, health.Any(down, replica)), health.StatusDegraded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := statusOf(tt.check); got != tt.want {
+				t.Fatalf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestQuorum_Result(t *testing.T) {
+	down := fixed("down", health.StatusUnhealthy, errors.New("connection refused"))
+	unnamed := fixed("", health.StatusUnhealthy, nil)
+
+	r := health.Quorum(2, fixed("primary", health.StatusHealthy, nil), down, unnamed).Check(context.Background())
+
+	if r.Metadata["passing"] != "1 of 3" {
+		t.Fatalf("expected passing count, got %v", r.Metadata)
+	}
+	if r.Metadata["primary.addr"] != "primary:5432" || r.Metadata["down.addr"] != "down:5432" {
+		t.Fatalf("expected child metadata prefixed by name, got %v", r.Metadata)
+	}
+	for _, want := range []string{"down: connection refused", "check 3: unhealthy"} {
+		if !strings.Contains(r.Error.Error(), want) {
+			t.Errorf("expected error to contain %q, got %q", want, r.Error)
+		}
+	}
+	if strings.Contains(r.Error.Error(), "primary") {
+		t.Errorf("expected passing child to be left out of the error, got %q", r.Error)
+	}
+	if r.ErrorSince.IsZero() {
+		t.Error("expected ErrorSince to be set")
+	}
+}
+
+func TestQuorum_Concurrent(t *testing.T) {
+	var running, peak atomic.Int32
+	slow := health.CheckerFunc(func(context.Context) *health.CheckResult {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(50 * time.Millisecond)
+		return &health.CheckResult{Status: health.StatusHealthy}
+	})
+
+	start := time.Now()
+	r := health.All(slow, slow, slow).Check(context.Background())
+	if peak.Load() != 3 {
+		t.Fatalf("expected children to run concurrently, peak was %d", peak.Load())
+	}
+	if r.Duration < 50*time.Millisecond || r.Duration > time.Since(start) {
+		t.Fatalf("expected combined duration to cover the children, got %s", r.Duration)
+	}
+}
+
+func TestQuorum_PanickingChild(t *testing.T) {
+	boom := health.CheckerFunc(func(context.Context) *health.CheckResult {
+		panic("boom")
+	})
+
+	r := health.Any(fixed("primary", health.StatusHealthy, nil), boom).Check(context.Background())
+	if r.Status != health.StatusDegraded {
+		t.Fatalf("expected degraded with one panicking child, got %s", r.Status)
+	}
+	if r.Error == nil || !strings.Contains(r.Error.Error(), "check 2: checker panicked: boom") {
+		t.Fatalf("expected error naming the panic, got %v", r.Error)
+	}
+}
+
+func TestFallback_StopsAtFirstPass(t *testing.T) {
+	var calls atomic.Int32
+	counted := health.CheckerFunc(func(context.Context) *health.CheckResult {
+		calls.Add(1)
+		return &health.CheckResult{Status: health.StatusHealthy}
+	})
+
+	r := health.Fallback(fixed("primary", health.StatusUnhealthy, errors.New("timeout")), counted, counted).
+		Check(context.Background())
+	if calls.Load() != 1 {
+		t.Fatalf("expected fallback to stop at the first passing child, ran %d", calls.Load())
+	}
+	if r.Metadata["passed"] != "check 2" || !strings.Contains(r.Error.Error(), "primary: timeout") {
+		t.Fatalf("unexpected fallback result: %+v", r)
+	}
+}
+
+func TestInvert_Error(t *testing.T) {
+	r := health.Invert(fixed("maintenance-flag", health.StatusHealthy, nil)).Check(context.Background())
+	if r.Error == nil || r.Error.Error() != "maintenance-flag passed" {
+		t.Fatalf("expected inverted error naming the check, got %v", r.Error)
+	}
+
+	r = health.Invert(fixed("maintenance-flag", health.StatusUnhealthy, errors.New("not set"))).Check(context.Background())
+	if r.Error != nil {
+		t.Fatalf("expected no error from a passing inverted check, got %v", r.Error)
+	}
+}
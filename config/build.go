@@ -0,0 +1,191 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/schigh/health/v2"
+	"github.com/schigh/health/v2/manager/std"
+	"github.com/schigh/health/v2/reporter/httpserver"
+	"github.com/schigh/health/v2/reporter/stdout"
+)
+
+const (
+	reporterHTTPServer = "httpserver"
+	reporterStdout     = "stdout"
+)
+
+// Build validates the configuration and returns a new manager with its
+// checks and reporters added.
+func (c *Config) Build() (*std.Manager, error) {
+	mgr := &std.Manager{}
+	if err := c.Apply(mgr); err != nil {
+		return nil, err
+	}
+	return mgr, nil
+}
+
+// Apply validates the configuration and adds its checks and reporters to
+// mgr, which lets the caller set the manager's own fields first. Checkers
+// that hold resources, such as the database pool of a db check, are closed
+// when the manager stops. If any entry fails to build, nothing is added.
+func (c *Config) Apply(mgr *std.Manager) error {
+	checkers, reporters, err := c.build()
+	if err != nil {
+		return err
+	}
+
+	for i := range c.Checks {
+		if err := mgr.AddCheck(c.Checks[i].Name, checkers[i], c.Checks[i].addCheckOptions()...); err != nil {
+			return err
+		}
+		if closer, ok := checkers[i].(io.Closer); ok {
+			mgr.AddPreStopHook(func(context.Context) error {
+				return closer.Close()
+			})
+		}
+	}
+	for i := range c.Reporters {
+		if err := mgr.AddReporter(c.Reporters[i].name(), reporters[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// build validates the configuration and creates its checkers and reporters,
+// in configuration order. If any entry fails, the checkers created so far
+// are closed.
+func (c *Config) build() ([]health.Checker, []health.Reporter, error) {
+	if err := c.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	checkers := make([]health.Checker, len(c.Checks))
+	for i := range c.Checks {
+		checker, err := c.Checks[i].checker()
+		if err != nil {
+			closeCheckers(checkers)
+			return nil, nil, entryError("checks", i, c.Checks[i].Name, err.Error())
+		}
+		checkers[i] = checker
+	}
+
+	reporters := make([]health.Reporter, len(c.Reporters))
+	for i := range c.Reporters {
+		reporter, err := c.Reporters[i].reporter()
+		if err != nil {
+			closeCheckers(checkers)
+			return nil, nil, entryError("reporters", i, c.Reporters[i].name(), err.Error())
+		}
+		reporters[i] = reporter
+	}
+
+	return checkers, reporters, nil
+}
+
+func (c *Check) checker() (health.Checker, error) {
+	factory := lookupChecker(c.Type)
+	if factory == nil {
+		return nil, fmt.Errorf("unknown type %q", c.Type)
+	}
+	return factory(c.Name, c.Options)
+}
+
+func (c *Check) addCheckOptions() []health.AddCheckOption {
+	freq, interval := health.CheckOnce, c.Interval.std()
+	if interval > 0 {
+		freq = health.CheckAtInterval
+	}
+	if c.Delay > 0 {
+		freq |= health.CheckAfter
+	}
+
+	opts := []health.AddCheckOption{
+		health.WithCheckFrequency(freq, interval, c.Delay.std()),
+		health.WithFailureThreshold(c.FailureThreshold),
+		health.WithSuccessThreshold(c.SuccessThreshold),
+		health.WithCheckTimeout(c.Timeout.std()),
+		health.WithGroup(c.Group),
+		health.WithComponentType(c.ComponentType),
+	}
+	if c.Jitter > 0 {
+		opts = append(opts, health.WithJitter(c.Jitter))
+	}
+	if c.AffectsLiveness {
+		opts = append(opts, health.WithLivenessImpact())
+	}
+	if c.AffectsReadiness {
+		opts = append(opts, health.WithReadinessImpact())
+	}
+	if c.AffectsStartup {
+		opts = append(opts, health.WithStartupImpact())
+	}
+	if len(c.DependsOn) > 0 {
+		opts = append(opts, health.WithDependsOn(c.DependsOn...))
+	}
+	return opts
+}
+
+type httpServerOptions struct {
+	Addr           string `json:"addr"`
+	Port           int    `json:"port"`
+	LivenessRoute  string `json:"livenessRoute"`
+	ReadinessRoute string `json:"readinessRoute"`
+	StartupRoute   string `json:"startupRoute"`
+	ServiceName    string `json:"serviceName"`
+	ServiceVersion string `json:"serviceVersion"`
+}
+
+func (r *Reporter) reporter() (health.Reporter, error) {
+	switch r.Type {
+	case reporterHTTPServer:
+		var o httpServerOptions
+		if err := DecodeOptions(r.Options, &o); err != nil {
+			return nil, err
+		}
+		if o.Port < 0 || o.Port > 65535 {
+			return nil, fmt.Errorf("options.port %d is out of range", o.Port)
+		}
+		var opts []httpserver.Option
+		if o.Addr != "" {
+			opts = append(opts, httpserver.WithAddr(o.Addr))
+		}
+		if o.Port != 0 {
+			opts = append(opts, httpserver.WithPort(o.Port))
+		}
+		if o.LivenessRoute != "" {
+			opts = append(opts, httpserver.WithLivenessRoute(o.LivenessRoute))
+		}
+		if o.ReadinessRoute != "" {
+			opts = append(opts, httpserver.WithReadinessRoute(o.ReadinessRoute))
+		}
+		if o.StartupRoute != "" {
+			opts = append(opts, httpserver.WithStartupRoute(o.StartupRoute))
+		}
+		if o.ServiceName != "" {
+			opts = append(opts, httpserver.WithServiceName(o.ServiceName))
+		}
+		if o.ServiceVersion != "" {
+			opts = append(opts, httpserver.WithServiceVersion(o.ServiceVersion))
+		}
+		return httpserver.New(opts...), nil
+	case reporterStdout:
+		if err := DecodeOptions(r.Options, &struct{}{}); err != nil {
+			return nil, err
+		}
+		return &stdout.Reporter{}, nil
+	default:
+		return nil, fmt.Errorf("unknown type %q", r.Type)
+	}
+}
+
+func closeCheckers(checkers []health.Checker) {
+	for _, checker := range checkers {
+		if closer, ok := checker.(io.Closer); ok {
+			_ = closer.Close()
+		}
+	}
+}
@@ -0,0 +1,280 @@
+// Package config builds a std.Manager from a declarative JSON document
+// describing its checks and reporters:
+//
+//	{
+//	  "checks": [
+//	    {
+//	      "name": "postgres",
+//	      "type": "db",
+//	      "options": {"driver": "pgx", "dsn": "postgres://localhost/app"},
+//	      "interval": "10s",
+//	      "affectsReadiness": true,
+//	      "group": "storage",
+//	      "componentType": "datastore"
+//	    }
+//	  ],
+//	  "reporters": [
+//	    {"type": "httpserver", "options": {"port": 8181}}
+//	  ]
+//	}
+//
+// The built-in check types are tcp, http, dns, redis, db, downstream and
+// tls; others can be added with RegisterChecker. The Config types carry JSON
+// tags only, so YAML documents can be loaded by converting them to JSON
+// first, for example with sigs.k8s.io/yaml.
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/schigh/health/v2"
+)
+
+// Config describes the checks and reporters of a health manager.
+type Config struct {
+	Checks    []Check    `json:"checks"`
+	Reporters []Reporter `json:"reporters"`
+}
+
+// Check describes a single health check. Type names a registered checker
+// factory, which receives Options. A check with an Interval runs at that
+// interval; one without runs once.
+type Check struct {
+	Name    string          `json:"name"`
+	Type    string          `json:"type"`
+	Options json.RawMessage `json:"options,omitempty"`
+
+	Interval Duration `json:"interval,omitempty"`
+	Delay    Duration `json:"delay,omitempty"`
+	Jitter   float64  `json:"jitter,omitempty"`
+	Timeout  Duration `json:"timeout,omitempty"`
+
+	FailureThreshold int `json:"failureThreshold,omitempty"`
+	SuccessThreshold int `json:"successThreshold,omitempty"`
+
+	AffectsLiveness  bool `json:"affectsLiveness,omitempty"`
+	AffectsReadiness bool `json:"affectsReadiness,omitempty"`
+	AffectsStartup   bool `json:"affectsStartup,omitempty"`
+
+	Group         string   `json:"group,omitempty"`
+	ComponentType string   `json:"componentType,omitempty"`
+	DependsOn     []string `json:"dependsOn,omitempty"`
+}
+
+// Reporter describes a single reporter. Type is httpserver or stdout. Name
+// defaults to Type.
+type Reporter struct {
+	Name    string          `json:"name,omitempty"`
+	Type    string          `json:"type"`
+	Options json.RawMessage `json:"options,omitempty"`
+}
+
+// Duration is a time.Duration that is encoded in JSON as a string such as
+// "1m30s".
+type Duration time.Duration
+
+// UnmarshalJSON parses a duration string.
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("duration must be a string such as \"5s\": %w", err)
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// MarshalJSON encodes the duration as a string.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d Duration) std() time.Duration {
+	return time.Duration(d)
+}
+
+// Parse decodes and validates a JSON configuration document. Unknown fields
+// are rejected.
+func Parse(data []byte) (*Config, error) {
+	var cfg Config
+	if err := decodeStrict(data, &cfg); err != nil {
+		return nil, fmt.Errorf("%w.config: %w", health.ErrHealth, err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// Load reads and parses the configuration file at path.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w.config: %w", health.ErrHealth, err)
+	}
+	return Parse(data)
+}
+
+// Validate checks the configuration without building anything. Every
+// problem found is reported, each naming the offending entry.
+func (c *Config) Validate() error {
+	var errs []error
+
+	checks := make(map[string]struct{}, len(c.Checks))
+	for i := range c.Checks {
+		chk := &c.Checks[i]
+		fail := func(format string, args ...any) {
+			errs = append(errs, entryError("checks", i, chk.Name, fmt.Sprintf(format, args...)))
+		}
+
+		switch _, dup := checks[chk.Name]; {
+		case chk.Name == "":
+			fail("name is required")
+		case dup:
+			fail("duplicate name")
+		}
+		checks[chk.Name] = struct{}{}
+
+		switch {
+		case chk.Type == "":
+			fail("type is required")
+		case lookupChecker(chk.Type) == nil:
+			fail("unknown type %q", chk.Type)
+		}
+
+		if chk.Interval < 0 || chk.Delay < 0 || chk.Timeout < 0 {
+			fail("durations must not be negative")
+		}
+		if chk.Jitter < 0 || chk.Jitter > 1 {
+			fail("jitter must be between 0 and 1")
+		}
+		if chk.FailureThreshold < 0 || chk.SuccessThreshold < 0 {
+			fail("thresholds must not be negative")
+		}
+		for _, dep := range chk.DependsOn {
+			switch dep {
+			case "":
+				fail("dependsOn contains an empty name")
+			case chk.Name:
+				fail("check depends on itself")
+			}
+		}
+	}
+
+	errs = append(errs, c.dependencyCycles()...)
+
+	reporters := make(map[string]struct{}, len(c.Reporters))
+	for i := range c.Reporters {
+		rpt := &c.Reporters[i]
+		name := rpt.name()
+		fail := func(format string, args ...any) {
+			errs = append(errs, entryError("reporters", i, name, fmt.Sprintf(format, args...)))
+		}
+
+		if _, dup := reporters[name]; dup && name != "" {
+			fail("duplicate name")
+		}
+		reporters[name] = struct{}{}
+
+		switch rpt.Type {
+		case "":
+			fail("type is required")
+		case reporterHTTPServer, reporterStdout:
+		default:
+			fail("unknown type %q", rpt.Type)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// dependencyCycles reports each cycle among the checks' dependencies once,
+// naming the entry it was found from. Dependencies on names that are not
+// checks in this configuration are ignored.
+func (c *Config) dependencyCycles() []error {
+	index := make(map[string]int, len(c.Checks))
+	for i := range c.Checks {
+		if _, dup := index[c.Checks[i].Name]; !dup {
+			index[c.Checks[i].Name] = i
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make([]int, len(c.Checks))
+	var (
+		errs  []error
+		path  []string
+		visit func(i int)
+	)
+	visit = func(i int) {
+		state[i] = visiting
+		path = append(path, c.Checks[i].Name)
+		for _, dep := range c.Checks[i].DependsOn {
+			j, ok := index[dep]
+			if !ok || dep == c.Checks[i].Name {
+				continue
+			}
+			switch state[j] {
+			case unvisited:
+				visit(j)
+			case visiting:
+				cycle := append(slices.Clone(path[slices.Index(path, dep):]), dep)
+				errs = append(errs, entryError("checks", j, dep, "dependency cycle "+strings.Join(cycle, " -> ")))
+			}
+		}
+		path = path[:len(path)-1]
+		state[i] = done
+	}
+	for i := range c.Checks {
+		if state[i] == unvisited {
+			visit(i)
+		}
+	}
+	return errs
+}
+
+func (r *Reporter) name() string {
+	if r.Name != "" {
+		return r.Name
+	}
+	return r.Type
+}
+
+// entryError names the list entry a problem was found in, by name when it
+// has one and by position otherwise.
+func entryError(list string, i int, name, msg string) error {
+	if name == "" {
+		return fmt.Errorf("%w.config: %s[%d]: %s", health.ErrHealth, list, i, msg)
+	}
+	return fmt.Errorf("%w.config: %s[%d] %q: %s", health.ErrHealth, list, i, name, msg)
+}
+
+// DecodeOptions decodes a check's raw options into v, rejecting unknown
+// fields. Checker factories use it to read their options. Empty options
+// leave v unchanged.
+func DecodeOptions(options json.RawMessage, v any) error {
+	if len(bytes.TrimSpace(options)) == 0 {
+		return nil
+	}
+	return decodeStrict(options, v)
+}
+
+func decodeStrict(data []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	return dec.Decode(v)
+}
@@ -0,0 +1,294 @@
+package config_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/schigh/health/v2"
+	"github.com/schigh/health/v2/config"
+	"github.com/schigh/health/v2/reporter/test"
+)
+
+func TestParse_Validation(t *testing.T) {
+	_, err := config.Parse([]byte(`{
+		"checks": [
+			{"name": "cache", "type": "tcp", "options": {"address": "localhost:6379"}},
+			{"name": "cache", "type": "tcp", "options": {"address": "localhost:6380"}},
+			{"name": "queue", "type": "carrier-pigeon"},
+			{"type": "dns", "jitter": 2},
+			{"name": "x", "type": "tcp", "dependsOn": ["y"]},
+			{"name": "y", "type": "tcp", "dependsOn": ["x", "https://payments.internal"]}
+		],
+		"reporters": [
+			{"type": "syslog"}
+		]
+	}`))
+	if !errors.Is(err, health.ErrHealth) {
+		t.Fatalf("expected a health error, got %v", err)
+	}
+
+	for _, want := range []string{
+		`checks[1] "cache": duplicate name`,
+		`checks[2] "queue": unknown type "carrier-pigeon"`,
+		`checks[3]: name is required`,
+		`checks[3]: jitter must be between 0 and 1`,
+		`checks[4] "x": dependency cycle x -> y -> x`,
+		`reporters[0] "syslog": unknown type "syslog"`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error to contain %q, got:\n%v", want, err)
+		}
+	}
+}
+
+func TestParse_UnknownField(t *testing.T) {
+	_, err := config.Parse([]byte(`{"checks": [{"name": "db", "type": "tcp", "intervall": "5s"}]}`))
+	if err == nil || !strings.Contains(err.Error(), "intervall") {
+		t.Fatalf("expected unknown field error, got %v", err)
+	}
+
+	_, err = config.Parse([]byte(`{"checks": [{"name": "db", "type": "tcp", "interval": 5}]}`))
+	if err == nil || !strings.Contains(err.Error(), "duration") {
+		t.Fatalf("expected duration error, got %v", err)
+	}
+}
+
+func TestBuild_OptionErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		want string
+	}{
+		{
+			name: "missing address",
+			doc:  `{"checks": [{"name": "cache", "type": "tcp"}]}`,
+			want: `checks[0] "cache": options.address is required`,
+		},
+		{
+			name: "unknown option",
+			doc:  `{"checks": [{"name": "api", "type": "http", "options": {"url": "http://localhost", "verb": "GET"}}]}`,
+			want: `checks[0] "api": json: unknown field "verb"`,
+		},
+		{
+			name: "unregistered driver",
+			doc:  `{"checks": [{"name": "pg", "type": "db", "options": {"driver": "nope", "dsn": "x"}}]}`,
+			want: `checks[0] "pg": sql: unknown driver "nope"`,
+		},
+		{
+			name: "client certificate without key",
+			doc:  `{"checks": [{"name": "api", "type": "tls", "options": {"address": "localhost:443", "certFile": "client.pem"}}]}`,
+			want: `checks[0] "api": options.certFile and options.keyFile must be set together`,
+		},
+		{
+			name: "bad status range",
+			doc:  `{"checks": [{"name": "api", "type": "http", "options": {"url": "http://localhost", "expectedStatusRanges": [[299, 200]]}}]}`,
+			want: `checks[0] "api": options.expectedStatusRanges[0] must be [low, high]`,
+		},
+		{
+			name: "bad body pattern",
+			doc:  `{"checks": [{"name": "api", "type": "http", "options": {"url": "http://localhost", "bodyMatches": "("}}]}`,
+			want: `checks[0] "api": options.bodyMatches: error parsing regexp`,
+		},
+		{
+			name: "bad port",
+			doc:  `{"reporters": [{"name": "probes", "type": "httpserver", "options": {"port": 70000}}]}`,
+			want: `reporters[0] "probes": options.port 70000 is out of range`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := config.Parse([]byte(tt.doc))
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = cfg.Build()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestBuild_HTTPOptions(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.Method != http.MethodPost || r.Header.Get("Authorization") != "Bearer token" || string(body) != "ping" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("X-Version", "2")
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = io.WriteString(w, `{"status": "ok", "checks": [{"name": "db"}]}`)
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name    string
+		options string
+		want    health.Status
+	}{
+		{
+			name: "passing",
+			options: `"expectedStatusRanges": [[200, 299]], "bodyContains": "ok", "bodyMatches": "status.+ok",
+				"jsonPathEquals": {"status": "ok", "checks.0.name": "db"}, "responseHeaders": {"X-Version": "2"}`,
+			want: health.StatusHealthy,
+		},
+		{
+			name:    "json path mismatch",
+			options: `"expectedStatusRanges": [[200, 299]], "jsonPathEquals": {"status": "down"}`,
+			want:    health.StatusUnhealthy,
+		},
+		{
+			name:    "missing response header",
+			options: `"expectedStatusRanges": [[200, 299]], "responseHeaders": {"X-Region": ""}`,
+			want:    health.StatusUnhealthy,
+		},
+		{
+			name:    "status out of range",
+			options: `"expectedStatusRanges": [[200, 201]]`,
+			want:    health.StatusUnhealthy,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := fmt.Sprintf(`{"checks": [{"name": "api", "type": "http", "options": {"url": %q, "method": "POST",
+				"headers": {"Authorization": "Bearer token"}, "body": "ping", %s}}]}`, srv.URL, tt.options)
+			cfg, err := config.Parse([]byte(doc))
+			if err != nil {
+				t.Fatal(err)
+			}
+			mgr, err := cfg.Build()
+			if err != nil {
+				t.Fatal(err)
+			}
+			_ = mgr.AddReporter("test", &test.Reporter{})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			_ = mgr.Run(ctx)
+			defer func() { _ = mgr.Stop(ctx) }()
+
+			waitFor(t, 2*time.Second, func() bool {
+				hc, ok := mgr.CheckStatus("api")
+				return ok && hc.Status == tt.want
+			})
+		})
+	}
+}
+
+func TestBuild(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	cfg, err := config.Parse([]byte(`{
+		"checks": [
+			{
+				"name": "upstream",
+				"type": "tcp",
+				"options": {"address": "` + ln.Addr().String() + `", "timeout": "1s", "latencyWarning": "500ms"},
+				"interval": "50ms",
+				"affectsReadiness": true,
+				"group": "network",
+				"componentType": "tcp"
+			}
+		],
+		"reporters": [{"type": "httpserver", "options": {"addr": "127.0.0.1", "port": 8585}}]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mgr, err := cfg.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rpt := &test.Reporter{}
+	_ = mgr.AddReporter("test", rpt)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_ = mgr.Run(ctx)
+
+	waitFor(t, 2*time.Second, func() bool {
+		return rpt.Report().IsReady
+	})
+
+	hc := rpt.Report().HealthChecks["upstream"]
+	if hc.Group != "network" || hc.ComponentType != "tcp" {
+		t.Fatalf("expected group and component type from config, got %+v", hc)
+	}
+
+	_ = mgr.Stop(ctx)
+}
+
+// the registry is global, so the custom type is registered once however
+// many times the test runs
+var registerFlag = sync.OnceValue(func() error {
+	type flagOptions struct {
+		Up bool `json:"up"`
+	}
+	return config.RegisterChecker("flag", func(name string, options json.RawMessage) (health.Checker, error) {
+		var o flagOptions
+		if err := config.DecodeOptions(options, &o); err != nil {
+			return nil, err
+		}
+		return health.CheckerFunc(func(context.Context) *health.CheckResult {
+			if !o.Up {
+				return &health.CheckResult{Name: name, Status: health.StatusUnhealthy}
+			}
+			return &health.CheckResult{Name: name, Status: health.StatusHealthy}
+		}), nil
+	})
+})
+
+func TestRegisterChecker(t *testing.T) {
+	if err := registerFlag(); err != nil {
+		t.Fatal(err)
+	}
+
+	noop := func(string, json.RawMessage) (health.Checker, error) {
+		return nil, nil
+	}
+	if err := config.RegisterChecker("flag", noop); err == nil {
+		t.Fatal("expected error registering a type twice")
+	}
+	if err := config.RegisterChecker("tcp", noop); err == nil {
+		t.Fatal("expected error replacing a built-in type")
+	}
+
+	cfg, err := config.Parse([]byte(`{"checks": [{"name": "feature", "type": "flag", "options": {"up": true}, "affectsLiveness": true}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mgr, err := cfg.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = mgr.AddReporter("test", &test.Reporter{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_ = mgr.Run(ctx)
+
+	waitFor(t, 2*time.Second, func() bool {
+		s, ok := mgr.CheckStatus("feature")
+		return ok && s.Status == health.StatusHealthy
+	})
+
+	_ = mgr.Stop(ctx)
+}
@@ -0,0 +1,375 @@
+package config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"sync"
+
+	"github.com/schigh/health/v2"
+	"github.com/schigh/health/v2/checker/db"
+	"github.com/schigh/health/v2/checker/dns"
+	"github.com/schigh/health/v2/checker/downstream"
+	"github.com/schigh/health/v2/checker/http"
+	"github.com/schigh/health/v2/checker/redis"
+	"github.com/schigh/health/v2/checker/tcp"
+	tlschecker "github.com/schigh/health/v2/checker/tls"
+)
+
+// CheckerFactory creates a checker named name from a check's raw options,
+// typically decoded with DecodeOptions. If the returned checker implements
+// io.Closer, it is closed when the manager stops.
+type CheckerFactory func(name string, options json.RawMessage) (health.Checker, error)
+
+var (
+	factoriesMx sync.RWMutex
+	factories   = map[string]CheckerFactory{ //nolint:gochecknoglobals // registry
+		"tcp":        newTCPChecker,
+		"http":       newHTTPChecker,
+		"dns":        newDNSChecker,
+		"redis":      newRedisChecker,
+		"db":         newDBChecker,
+		"downstream": newDownstreamChecker,
+		"tls":        newTLSChecker,
+	}
+)
+
+// RegisterChecker makes a checker factory available under the given check
+// type, so that configurations can use it. Registering a type twice is an
+// error.
+func RegisterChecker(typ string, factory CheckerFactory) error {
+	factoriesMx.Lock()
+	defer factoriesMx.Unlock()
+
+	if typ == "" || factory == nil {
+		return fmt.Errorf("%w.config: checker type and factory are required", health.ErrHealth)
+	}
+	if _, dup := factories[typ]; dup {
+		return fmt.Errorf("%w.config: checker type %q is already registered", health.ErrHealth, typ)
+	}
+	factories[typ] = factory
+	return nil
+}
+
+func lookupChecker(typ string) CheckerFactory {
+	factoriesMx.RLock()
+	defer factoriesMx.RUnlock()
+	return factories[typ]
+}
+
+// latencyOptions are the latency thresholds accepted by every built-in check
+// type.
+type latencyOptions struct {
+	LatencyWarning  Duration `json:"latencyWarning"`
+	LatencyCritical Duration `json:"latencyCritical"`
+}
+
+func (o latencyOptions) latencySet() bool {
+	return o.LatencyWarning > 0 || o.LatencyCritical > 0
+}
+
+type tcpOptions struct {
+	latencyOptions
+	Address string   `json:"address"`
+	Timeout Duration `json:"timeout"`
+}
+
+func newTCPChecker(name string, options json.RawMessage) (health.Checker, error) {
+	var o tcpOptions
+	if err := DecodeOptions(options, &o); err != nil {
+		return nil, err
+	}
+	if o.Address == "" {
+		return nil, errors.New("options.address is required")
+	}
+	var opts []tcp.Option
+	if o.Timeout > 0 {
+		opts = append(opts, tcp.WithTimeout(o.Timeout.std()))
+	}
+	if o.latencySet() {
+		opts = append(opts, tcp.WithLatencyThresholds(o.LatencyWarning.std(), o.LatencyCritical.std()))
+	}
+	return tcp.NewChecker(name, o.Address, opts...), nil
+}
+
+type httpOptions struct {
+	latencyOptions
+	URL                  string            `json:"url"`
+	Method               string            `json:"method"`
+	Headers              map[string]string `json:"headers"`
+	Body                 string            `json:"body"`
+	ExpectedStatus       int               `json:"expectedStatus"`
+	ExpectedStatusRanges [][]int           `json:"expectedStatusRanges"`
+	BodyContains         string            `json:"bodyContains"`
+	BodyMatches          string            `json:"bodyMatches"`
+	JSONPathEquals       map[string]string `json:"jsonPathEquals"`
+	ResponseHeaders      map[string]string `json:"responseHeaders"`
+	Timeout              Duration          `json:"timeout"`
+}
+
+// newHTTPChecker accepts status ranges as [low, high] pairs. The map options
+// are applied in key order.
+func newHTTPChecker(name string, options json.RawMessage) (health.Checker, error) {
+	var o httpOptions
+	if err := DecodeOptions(options, &o); err != nil {
+		return nil, err
+	}
+	if o.URL == "" {
+		return nil, errors.New("options.url is required")
+	}
+	var opts []http.Option
+	if o.Method != "" {
+		opts = append(opts, http.WithMethod(o.Method))
+	}
+	for _, key := range sortedKeys(o.Headers) {
+		opts = append(opts, http.WithHeader(key, o.Headers[key]))
+	}
+	if o.Body != "" {
+		opts = append(opts, http.WithBody([]byte(o.Body)))
+	}
+	if o.ExpectedStatus != 0 {
+		opts = append(opts, http.WithExpectedStatus(o.ExpectedStatus))
+	}
+	for i, r := range o.ExpectedStatusRanges {
+		if len(r) != 2 || r[0] > r[1] {
+			return nil, fmt.Errorf("options.expectedStatusRanges[%d] must be [low, high]", i)
+		}
+		opts = append(opts, http.WithExpectedStatusRange(r[0], r[1]))
+	}
+	if o.BodyContains != "" {
+		opts = append(opts, http.WithBodyContains(o.BodyContains))
+	}
+	if o.BodyMatches != "" {
+		re, err := regexp.Compile(o.BodyMatches)
+		if err != nil {
+			return nil, fmt.Errorf("options.bodyMatches: %w", err)
+		}
+		opts = append(opts, http.WithBodyMatches(re))
+	}
+	for _, path := range sortedKeys(o.JSONPathEquals) {
+		opts = append(opts, http.WithJSONPathEquals(path, o.JSONPathEquals[path]))
+	}
+	for _, key := range sortedKeys(o.ResponseHeaders) {
+		opts = append(opts, http.WithResponseHeader(key, o.ResponseHeaders[key]))
+	}
+	if o.Timeout > 0 {
+		opts = append(opts, http.WithTimeout(o.Timeout.std()))
+	}
+	if o.latencySet() {
+		opts = append(opts, http.WithLatencyThresholds(o.LatencyWarning.std(), o.LatencyCritical.std()))
+	}
+	return http.NewChecker(name, o.URL, opts...), nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+type dnsOptions struct {
+	latencyOptions
+	Hostname string   `json:"hostname"`
+	Timeout  Duration `json:"timeout"`
+}
+
+func newDNSChecker(name string, options json.RawMessage) (health.Checker, error) {
+	var o dnsOptions
+	if err := DecodeOptions(options, &o); err != nil {
+		return nil, err
+	}
+	if o.Hostname == "" {
+		return nil, errors.New("options.hostname is required")
+	}
+	var opts []dns.Option
+	if o.Timeout > 0 {
+		opts = append(opts, dns.WithTimeout(o.Timeout.std()))
+	}
+	if o.latencySet() {
+		opts = append(opts, dns.WithLatencyThresholds(o.LatencyWarning.std(), o.LatencyCritical.std()))
+	}
+	return dns.NewChecker(name, o.Hostname, opts...), nil
+}
+
+type redisOptions struct {
+	latencyOptions
+	Address          string   `json:"address"`
+	Username         string   `json:"username"`
+	Password         string   `json:"password"`
+	Database         int      `json:"database"`
+	TLS              bool     `json:"tls"`
+	Info             bool     `json:"info"`
+	MaxFragmentation float64  `json:"maxFragmentation"`
+	Timeout          Duration `json:"timeout"`
+}
+
+func newRedisChecker(name string, options json.RawMessage) (health.Checker, error) {
+	var o redisOptions
+	if err := DecodeOptions(options, &o); err != nil {
+		return nil, err
+	}
+	if o.Address == "" {
+		return nil, errors.New("options.address is required")
+	}
+	var opts []redis.Option
+	if o.Username != "" {
+		opts = append(opts, redis.WithUsername(o.Username))
+	}
+	if o.Password != "" {
+		opts = append(opts, redis.WithPassword(o.Password))
+	}
+	if o.Database != 0 {
+		opts = append(opts, redis.WithDatabase(o.Database))
+	}
+	if o.TLS {
+		opts = append(opts, redis.WithTLS(&tls.Config{MinVersion: tls.VersionTLS12}))
+	}
+	if o.Info {
+		opts = append(opts, redis.WithInfo())
+	}
+	if o.MaxFragmentation > 0 {
+		opts = append(opts, redis.WithMaxFragmentation(o.MaxFragmentation))
+	}
+	if o.Timeout > 0 {
+		opts = append(opts, redis.WithTimeout(o.Timeout.std()))
+	}
+	if o.latencySet() {
+		opts = append(opts, redis.WithLatencyThresholds(o.LatencyWarning.std(), o.LatencyCritical.std()))
+	}
+	return redis.NewChecker(name, o.Address, opts...), nil
+}
+
+type downstreamOptions struct {
+	latencyOptions
+	URL            string   `json:"url"`
+	RequiredChecks []string `json:"requiredChecks"`
+	Timeout        Duration `json:"timeout"`
+}
+
+func newDownstreamChecker(name string, options json.RawMessage) (health.Checker, error) {
+	var o downstreamOptions
+	if err := DecodeOptions(options, &o); err != nil {
+		return nil, err
+	}
+	if o.URL == "" {
+		return nil, errors.New("options.url is required")
+	}
+	var opts []downstream.Option
+	if len(o.RequiredChecks) > 0 {
+		opts = append(opts, downstream.WithRequiredChecks(o.RequiredChecks...))
+	}
+	if o.Timeout > 0 {
+		opts = append(opts, downstream.WithTimeout(o.Timeout.std()))
+	}
+	if o.latencySet() {
+		opts = append(opts, downstream.WithLatencyThresholds(o.LatencyWarning.std(), o.LatencyCritical.std()))
+	}
+	return downstream.NewChecker(name, o.URL, opts...), nil
+}
+
+type tlsOptions struct {
+	latencyOptions
+	Address       string   `json:"address"`
+	ServerName    string   `json:"serverName"`
+	CAFile        string   `json:"caFile"`
+	CertFile      string   `json:"certFile"`
+	KeyFile       string   `json:"keyFile"`
+	ExpiryWarning Duration `json:"expiryWarning"`
+	Timeout       Duration `json:"timeout"`
+}
+
+// newTLSChecker reads the root pool and client certificate from PEM files.
+func newTLSChecker(name string, options json.RawMessage) (health.Checker, error) {
+	var o tlsOptions
+	if err := DecodeOptions(options, &o); err != nil {
+		return nil, err
+	}
+	if o.Address == "" {
+		return nil, errors.New("options.address is required")
+	}
+	var opts []tlschecker.Option
+	if o.ServerName != "" {
+		opts = append(opts, tlschecker.WithServerName(o.ServerName))
+	}
+	if o.CAFile != "" {
+		pem, err := os.ReadFile(o.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("options.caFile: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("options.caFile: no certificates in %s", o.CAFile)
+		}
+		opts = append(opts, tlschecker.WithRootCAs(pool))
+	}
+	if (o.CertFile == "") != (o.KeyFile == "") {
+		return nil, errors.New("options.certFile and options.keyFile must be set together")
+	}
+	if o.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(o.CertFile, o.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("options.certFile: %w", err)
+		}
+		opts = append(opts, tlschecker.WithClientCertificates(cert))
+	}
+	if o.ExpiryWarning > 0 {
+		opts = append(opts, tlschecker.WithExpiryWarning(o.ExpiryWarning.std()))
+	}
+	if o.Timeout > 0 {
+		opts = append(opts, tlschecker.WithTimeout(o.Timeout.std()))
+	}
+	if o.latencySet() {
+		opts = append(opts, tlschecker.WithLatencyThresholds(o.LatencyWarning.std(), o.LatencyCritical.std()))
+	}
+	return tlschecker.NewChecker(name, o.Address, opts...), nil
+}
+
+type dbOptions struct {
+	latencyOptions
+	Driver  string   `json:"driver"`
+	DSN     string   `json:"dsn"`
+	Timeout Duration `json:"timeout"`
+}
+
+// dbChecker owns the connection pool it pings, closing it with the manager.
+type dbChecker struct {
+	*db.Checker
+	db *sql.DB
+}
+
+func (c *dbChecker) Close() error {
+	return c.db.Close()
+}
+
+// newDBChecker opens a database/sql connection pool. The driver must be
+// registered by the application, usually by importing it.
+func newDBChecker(name string, options json.RawMessage) (health.Checker, error) {
+	var o dbOptions
+	if err := DecodeOptions(options, &o); err != nil {
+		return nil, err
+	}
+	if o.Driver == "" || o.DSN == "" {
+		return nil, errors.New("options.driver and options.dsn are required")
+	}
+	pool, err := sql.Open(o.Driver, o.DSN)
+	if err != nil {
+		return nil, err
+	}
+	var opts []db.Option
+	if o.Timeout > 0 {
+		opts = append(opts, db.WithTimeout(o.Timeout.std()))
+	}
+	if o.latencySet() {
+		opts = append(opts, db.WithLatencyThresholds(o.LatencyWarning.std(), o.LatencyCritical.std()))
+	}
+	return &dbChecker{Checker: db.NewChecker(name, pool, opts...), db: pool}, nil
+}
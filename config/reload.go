@@ -0,0 +1,362 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/schigh/health/v2"
+	"github.com/schigh/health/v2/manager/std"
+)
+
+const (
+	// DefaultReloadCheck is the name of the check reporting the outcome of
+	// the last reload.
+	DefaultReloadCheck = "config-reload"
+
+	// DefaultPollInterval is how often a Reloader looks for changes to the
+	// configuration file.
+	DefaultPollInterval = 5 * time.Second
+)
+
+// Reloader keeps the checks of a running manager in line with a
+// configuration file. On each reload, checks that were added to the file are
+// started, checks that were removed are stopped, and checks whose entry
+// changed are restarted. Unchanged checks keep running with their results,
+// so readiness does not blip.
+//
+// A reload that fails, for example because the file no longer parses, keeps
+// the previous configuration. The failure is logged through the manager's
+// Logger and reported as an unhealthy result of the reload check, which
+// does not affect liveness or readiness. Reporters are only read by Load;
+// changes to them take effect when the service restarts.
+type Reloader struct {
+	// Path is the configuration file.
+	Path string
+
+	// Manager receives the configured checks and reporters.
+	Manager *std.Manager
+
+	// CheckName is the name of the check reporting reload status. Default:
+	// DefaultReloadCheck.
+	CheckName string
+
+	// PollInterval is how often Watch looks for changes to the file. A
+	// negative value disables polling, leaving only SIGHUP. Default:
+	// DefaultPollInterval.
+	PollInterval time.Duration
+
+	mu       sync.Mutex
+	logger   health.Logger
+	current  *Config
+	checkers map[string]health.Checker
+	status   *std.PassiveCheck
+	stamp    fileStamp
+}
+
+// fileStamp identifies a version of the configuration file.
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// Load reads the configuration file and adds its checks and reporters, along
+// with the reload check, to the manager. It must be called once, before the
+// manager runs.
+func (r *Reloader) Load() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.current != nil {
+		return fmt.Errorf("%w.config: %s is already loaded", health.ErrHealth, r.Path)
+	}
+	r.logger = r.Manager.Logger
+	if r.logger == nil {
+		r.logger = health.NoOpLogger{}
+	}
+
+	r.stamp = r.statFile()
+	cfg, err := r.load()
+	if err != nil {
+		return err
+	}
+	checkers, reporters, err := cfg.build()
+	if err != nil {
+		return err
+	}
+
+	r.status, err = r.Manager.AddPassiveCheck(r.checkName(), 0)
+	if err != nil {
+		closeCheckers(checkers)
+		return err
+	}
+	r.status.SetHealthy()
+
+	r.checkers = make(map[string]health.Checker, len(checkers))
+	for i := range cfg.Checks {
+		if err := r.Manager.AddCheck(cfg.Checks[i].Name, checkers[i], cfg.Checks[i].addCheckOptions()...); err != nil {
+			closeCheckers(checkers)
+			return err
+		}
+		r.checkers[cfg.Checks[i].Name] = checkers[i]
+	}
+	for i := range cfg.Reporters {
+		if err := r.Manager.AddReporter(cfg.Reporters[i].name(), reporters[i]); err != nil {
+			return err
+		}
+	}
+	r.Manager.AddPreStopHook(r.close)
+
+	r.current = cfg
+	return nil
+}
+
+// Reload re-reads the configuration file and applies the differences in its
+// checks to the manager. On failure the previous configuration stays in
+// place and the error is returned.
+func (r *Reloader) Reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.current == nil {
+		return fmt.Errorf("%w.config: %s has not been loaded", health.ErrHealth, r.Path)
+	}
+
+	r.stamp = r.statFile()
+	if err := r.reload(); err != nil {
+		r.logger.Error("configuration reload failed, keeping the previous configuration", "path", r.Path, "error", err)
+		r.status.SetUnhealthy(err)
+		return err
+	}
+	r.status.SetHealthy()
+	return nil
+}
+
+// Watch reloads the configuration on SIGHUP and, unless PollInterval is
+// negative, whenever the file's size or modification time changes. It
+// blocks until the context is done. Load must have been called first.
+func (r *Reloader) Watch(ctx context.Context) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	var poll <-chan time.Time
+	if interval := r.pollInterval(); interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		poll = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			r.logger.Info("received SIGHUP, reloading configuration", "path", r.Path)
+			_ = r.Reload()
+		case <-poll:
+			if r.changed() {
+				r.logger.Info("configuration file changed, reloading", "path", r.Path)
+				_ = r.Reload()
+			}
+		}
+	}
+}
+
+// reload applies the checks of the current file. New and changed checkers
+// are all built before the manager is touched, so an entry that fails to
+// build leaves everything as it was. If the manager rejects a check, the
+// checks already applied are rolled back and the new checkers are closed.
+// The caller must hold mu.
+func (r *Reloader) reload() error {
+	cfg, err := r.load()
+	if err != nil {
+		return err
+	}
+
+	previous := make(map[string]*Check, len(r.current.Checks))
+	for i := range r.current.Checks {
+		previous[r.current.Checks[i].Name] = &r.current.Checks[i]
+	}
+
+	var apply, changed []int
+	removed := make(map[string]*Check, len(previous))
+	for name, chk := range previous {
+		removed[name] = chk
+	}
+	built := make(map[string]health.Checker)
+	for i := range cfg.Checks {
+		chk := &cfg.Checks[i]
+		prev, ok := previous[chk.Name]
+		delete(removed, chk.Name)
+		switch {
+		case !ok:
+		case !sameJSON(prev, chk):
+			changed = append(changed, i)
+		default:
+			continue
+		}
+		apply = append(apply, i)
+
+		checker, err := chk.checker()
+		if err != nil {
+			closeCheckers(mapValues(built))
+			return entryError("checks", i, chk.Name, err.Error())
+		}
+		built[chk.Name] = checker
+	}
+
+	for name := range removed {
+		if err := r.Manager.RemoveCheck(name); err != nil {
+			r.logger.Warn("removed check was not registered", "check", name, "error", err)
+		}
+	}
+	for n, i := range apply {
+		chk := &cfg.Checks[i]
+		if err := r.Manager.AddCheck(chk.Name, built[chk.Name], chk.addCheckOptions()...); err != nil {
+			r.rollback(cfg, apply[:n], previous, removed)
+			closeCheckers(mapValues(built))
+			return entryError("checks", i, chk.Name, err.Error())
+		}
+	}
+
+	for name := range removed {
+		r.closeChecker(name)
+	}
+	for _, i := range apply {
+		name := cfg.Checks[i].Name
+		r.closeChecker(name)
+		r.checkers[name] = built[name]
+	}
+
+	if !sameJSON(r.current.Reporters, cfg.Reporters) {
+		r.logger.Warn("reporter configuration changed; reporters are only configured at startup", "path", r.Path)
+	}
+
+	r.current = cfg
+	r.logger.Info("configuration reloaded", "path", r.Path,
+		"added", len(apply)-len(changed), "changed", len(changed), "removed", len(removed))
+	return nil
+}
+
+// rollback undoes a partly applied reload: the checks of cfg at the applied
+// indexes are removed or returned to their previous entry, and the removed
+// checks are added back, each with its running checker. The caller must
+// hold mu.
+func (r *Reloader) rollback(cfg *Config, applied []int, previous, removed map[string]*Check) {
+	restore := func(prev *Check) {
+		if err := r.Manager.AddCheck(prev.Name, r.checkers[prev.Name], prev.addCheckOptions()...); err != nil {
+			r.logger.Warn("failed to restore check", "check", prev.Name, "error", err)
+		}
+	}
+
+	for _, i := range applied {
+		name := cfg.Checks[i].Name
+		if prev, ok := previous[name]; ok {
+			restore(prev)
+			continue
+		}
+		if err := r.Manager.RemoveCheck(name); err != nil {
+			r.logger.Warn("failed to remove added check", "check", name, "error", err)
+		}
+	}
+	for _, prev := range removed {
+		restore(prev)
+	}
+}
+
+// load parses the configuration file, which must not use the reload
+// check's name for one of its own checks.
+func (r *Reloader) load() (*Config, error) {
+	cfg, err := Load(r.Path)
+	if err != nil {
+		return nil, err
+	}
+	name := r.checkName()
+	for i := range cfg.Checks {
+		if cfg.Checks[i].Name == name {
+			return nil, entryError("checks", i, name, "name is reserved for the reload check")
+		}
+	}
+	return cfg, nil
+}
+
+// changed reports whether the file differs from the version last read.
+func (r *Reloader) changed() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.statFile() != r.stamp
+}
+
+// statFile returns the stamp of the file, or a zero stamp if it cannot be
+// read.
+func (r *Reloader) statFile() fileStamp {
+	info, err := os.Stat(r.Path)
+	if err != nil {
+		return fileStamp{}
+	}
+	return fileStamp{modTime: info.ModTime(), size: info.Size()}
+}
+
+// closeChecker closes the named checker if it holds resources and forgets
+// it. The caller must hold mu.
+func (r *Reloader) closeChecker(name string) {
+	if closer, ok := r.checkers[name].(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			r.logger.Warn("failed to close checker", "check", name, "error", err)
+		}
+	}
+	delete(r.checkers, name)
+}
+
+// close closes every checker that holds resources, when the manager stops.
+func (r *Reloader) close(context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var errs []error
+	for _, checker := range r.checkers {
+		if closer, ok := checker.(io.Closer); ok {
+			errs = append(errs, closer.Close())
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r *Reloader) checkName() string {
+	if r.CheckName != "" {
+		return r.CheckName
+	}
+	return DefaultReloadCheck
+}
+
+func (r *Reloader) pollInterval() time.Duration {
+	if r.PollInterval != 0 {
+		return r.PollInterval
+	}
+	return DefaultPollInterval
+}
+
+// sameJSON reports whether a and b encode to the same JSON. Raw options are
+// compacted when encoded, so whitespace changes are not differences.
+func sameJSON(a, b any) bool {
+	ja, errA := json.Marshal(a)
+	jb, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(ja, jb)
+}
+
+func mapValues(m map[string]health.Checker) []health.Checker {
+	out := make([]health.Checker, 0, len(m))
+	for _, v := range m {
+		out = append(out, v)
+	}
+	return out
+}
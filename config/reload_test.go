@@ -0,0 +1,247 @@
+package config_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/schigh/health/v2"
+	"github.com/schigh/health/v2/config"
+	"github.com/schigh/health/v2/manager/std"
+	"github.com/schigh/health/v2/reporter/test"
+)
+
+func tcpCheck(name, addr, interval string) string {
+	return fmt.Sprintf(`{"name": %q, "type": "tcp", "options": {"address": %q}, "interval": %q, "affectsReadiness": true}`,
+		name, addr, interval)
+}
+
+func writeConfig(t *testing.T, path string, checks ...string) {
+	t.Helper()
+	doc := `{"checks": [` + strings.Join(checks, ",") + `]}`
+	if err := os.WriteFile(path, []byte(doc), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func waitFor(t *testing.T, timeout time.Duration, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for condition")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestReloader(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	addr := ln.Addr().String()
+
+	path := filepath.Join(t.TempDir(), "health.json")
+	writeConfig(t, path, tcpCheck("a", addr, "20ms"), tcpCheck("b", addr, "20ms"), tcpCheck("c", addr, "20ms"))
+
+	mgr := &std.Manager{}
+	rl := &config.Reloader{Path: path, Manager: mgr, PollInterval: -1}
+	if err := rl.Load(); err != nil {
+		t.Fatal(err)
+	}
+	rpt := &test.Reporter{}
+	_ = mgr.AddReporter("test", rpt)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_ = mgr.Run(ctx)
+	waitFor(t, 2*time.Second, func() bool {
+		return rpt.Report().IsReady
+	})
+
+	events, unsubscribe := mgr.Subscribe(16)
+	defer unsubscribe()
+
+	// a is unchanged, b is removed, c changes interval and d is added
+	writeConfig(t, path, tcpCheck("a", addr, "20ms"), tcpCheck("c", addr, "30ms"), tcpCheck("d", addr, "20ms"))
+	if err := rl.Reload(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := mgr.CheckStatus("a"); !ok {
+		t.Fatal("expected unchanged check to keep its result")
+	}
+	waitFor(t, 2*time.Second, func() bool {
+		s := mgr.Snapshot()
+		return s.Checks["b"] == nil && s.Checks["c"] != nil && s.Checks["d"] != nil
+	})
+	for len(events) > 0 {
+		if ev := <-events; ev.Kind == std.EventReadiness {
+			t.Fatalf("expected readiness to hold across the reload, got %+v", ev)
+		}
+	}
+
+	// a broken file keeps the previous checks and fails the reload check
+	if err := os.WriteFile(path, []byte(`{"checks": [`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := rl.Reload(); err == nil {
+		t.Fatal("expected reload of a broken file to fail")
+	}
+	waitFor(t, 2*time.Second, func() bool {
+		hc, ok := mgr.CheckStatus(config.DefaultReloadCheck)
+		return ok && hc.Status == health.StatusUnhealthy && hc.Error != nil
+	})
+	if s := mgr.Snapshot(); s.Checks["d"] == nil || !s.Ready {
+		t.Fatalf("expected previous configuration to stay in place, got %+v", s)
+	}
+
+	writeConfig(t, path, tcpCheck("a", addr, "20ms"))
+	if err := rl.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, 2*time.Second, func() bool {
+		hc, ok := mgr.CheckStatus(config.DefaultReloadCheck)
+		return ok && hc.Status == health.StatusHealthy
+	})
+
+	_ = mgr.Stop(ctx)
+}
+
+func TestReloader_Reserved(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "health.json")
+	writeConfig(t, path, tcpCheck(config.DefaultReloadCheck, "127.0.0.1:1", "1s"))
+
+	rl := &config.Reloader{Path: path, Manager: &std.Manager{}}
+	if err := rl.Load(); err == nil || !strings.Contains(err.Error(), "reserved") {
+		t.Fatalf("expected reserved name error, got %v", err)
+	}
+}
+
+// closeCount counts the closes of "closer" checkers. The registry is global,
+// so the type is registered once however many times the test runs.
+var (
+	closeCount     atomic.Int32
+	registerCloser = sync.OnceValue(func() error {
+		return config.RegisterChecker("closer", func(name string, _ json.RawMessage) (health.Checker, error) {
+			return &closerChecker{name: name}, nil
+		})
+	})
+)
+
+type closerChecker struct{ name string }
+
+func (c *closerChecker) Check(context.Context) *health.CheckResult {
+	return &health.CheckResult{Name: c.name, Status: health.StatusHealthy}
+}
+
+func (c *closerChecker) Close() error {
+	closeCount.Add(1)
+	return nil
+}
+
+func TestReloader_AddCheckFails(t *testing.T) {
+	if err := registerCloser(); err != nil {
+		t.Fatal(err)
+	}
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	addr := ln.Addr().String()
+
+	path := filepath.Join(t.TempDir(), "health.json")
+	writeConfig(t, path, tcpCheck("a", addr, "20ms"), tcpCheck("b", addr, "20ms"))
+
+	mgr := &std.Manager{}
+	rl := &config.Reloader{Path: path, Manager: mgr, PollInterval: -1}
+	if err := rl.Load(); err != nil {
+		t.Fatal(err)
+	}
+	_ = mgr.AddReporter("test", &test.Reporter{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_ = mgr.Run(ctx)
+
+	// a check outside the file makes the added check a dependency cycle,
+	// which the manager rejects after a has been changed and b removed
+	_ = mgr.AddCheck("outside", health.CheckerFunc(func(context.Context) *health.CheckResult {
+		return &health.CheckResult{Name: "outside", Status: health.StatusHealthy}
+	}), health.WithDependsOn("late"))
+	waitFor(t, 2*time.Second, func() bool {
+		s := mgr.Snapshot()
+		return s.Checks["a"] != nil && s.Checks["b"] != nil
+	})
+
+	closed := closeCount.Load()
+	writeConfig(t, path, tcpCheck("a", addr, "30ms"),
+		`{"name": "late", "type": "closer", "dependsOn": ["outside"]}`)
+	if err := rl.Reload(); err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Fatalf("expected dependency cycle error, got %v", err)
+	}
+
+	if got := closeCount.Load() - closed; got != 1 {
+		t.Fatalf("expected the unused checker to be closed once, got %d", got)
+	}
+	waitFor(t, 2*time.Second, func() bool {
+		s := mgr.Snapshot()
+		return s.Checks["a"] != nil && s.Checks["b"] != nil && s.Checks["late"] == nil
+	})
+	hc, ok := mgr.CheckStatus(config.DefaultReloadCheck)
+	if !ok || hc.Status != health.StatusUnhealthy {
+		t.Fatalf("expected failed reload check, got %+v", hc)
+	}
+
+	// the previous configuration is still current, so dropping the bad
+	// entry reloads as a change of a only
+	writeConfig(t, path, tcpCheck("a", addr, "30ms"), tcpCheck("b", addr, "20ms"))
+	if err := rl.Reload(); err != nil {
+		t.Fatal(err)
+	}
+
+	_ = mgr.Stop(ctx)
+}
+
+func TestReloader_Watch(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	addr := ln.Addr().String()
+
+	path := filepath.Join(t.TempDir(), "health.json")
+	writeConfig(t, path, tcpCheck("a", addr, "20ms"))
+
+	mgr := &std.Manager{}
+	rl := &config.Reloader{Path: path, Manager: mgr, PollInterval: 10 * time.Millisecond}
+	if err := rl.Load(); err != nil {
+		t.Fatal(err)
+	}
+	_ = mgr.AddReporter("test", &test.Reporter{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_ = mgr.Run(ctx)
+	go rl.Watch(ctx)
+
+	writeConfig(t, path, tcpCheck("a", addr, "20ms"), tcpCheck("added", addr, "20ms"))
+	waitFor(t, 2*time.Second, func() bool {
+		_, ok := mgr.CheckStatus("added")
+		return ok
+	})
+
+	_ = mgr.Stop(ctx)
+}
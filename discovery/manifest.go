@@ -17,6 +17,10 @@ type Manifest struct {
 	// Checks describes each registered health check.
 	Checks []CheckEntry `json:"checks"`
 
+	// Overrides holds the reasons for any operator overrides of the
+	// "liveness" or "readiness" probes, keyed by probe.
+	Overrides map[string]string `json:"overrides,omitempty"`
+
 	// Timestamp of when this manifest was generated.
 	Timestamp time.Time `json:"timestamp"`
 }
@@ -34,6 +38,7 @@ type CheckEntry struct {
 	Duration         string   `json:"duration,omitempty"`
 	LastCheck        string   `json:"lastCheck,omitempty"`
 	Error            string   `json:"error,omitempty"`
+	Override         string   `json:"override,omitempty"`
 }
 
 // Node represents a service in a discovered dependency graph.
@@ -59,7 +59,10 @@ func main() {
 	case err := <-errChan:
 		log.Fatalf("manager error: %v", err)
 	case <-ctx.Done():
-		if err := mgr.Stop(ctx); err != nil {
+		// ctx is cancelled, so stop with a fresh one
+		stopCtx, stopCancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer stopCancel()
+		if err := mgr.Stop(stopCtx); err != nil {
 			log.Printf("stop error: %v", err)
 		}
 	}
@@ -48,7 +48,11 @@ func main() {
 		case err := <-errChan:
 			log.Printf("error: %v", err)
 		case <-ctx.Done():
-			_ = mgr.Stop(ctx) // remember to stop the manager
+			// remember to stop the manager, with a fresh context since ctx
+			// is cancelled
+			stopCtx, stopCancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer stopCancel()
+			_ = mgr.Stop(stopCtx)
 			return
 		}
 	}
@@ -36,16 +36,17 @@ type Manager interface {
 	// a check interval, and any affects on liveness or readiness. All added
 	// health checks must be named uniquely. Adding a check with the same name
 	// as an existing health check (case-insensitive), will overwrite the previous
-	// check. Attempting to add a check after the manager is running will return
-	// an error.
+	// check. Implementations may allow checks to be added after the manager is
+	// running; see the implementation's documentation.
 	AddCheck(name string, c Checker, opts ...AddCheckOption) error
 
 	// AddReporter adds a named health reporter to the manager. Every time a
 	// health check is reported, the manager will relay the update to the
 	// reporters. All added health reporters must be named uniquely.
 	// Adding a reporter with the same name as an existing health reporter
-	// (case-insensitive), will overwrite the previous reporter. Attempting to
-	// add a reporter after the manager is running will return an error.
+	// (case-insensitive), will overwrite the previous reporter. Implementations
+	// may allow reporters to be added after the manager is running; see the
+	// implementation's documentation.
 	AddReporter(name string, r Reporter) error
 }
 
@@ -84,6 +85,36 @@ type Reporter interface {
 	UpdateHealthChecks(context.Context, map[string]*CheckResult)
 }
 
+// CheckRemover is an optional interface a [Reporter] may implement to be told
+// when health checks are removed from a running manager. Reporters that do not
+// implement CheckRemover will keep reporting the last result they received for
+// a removed check.
+type CheckRemover interface {
+	// RemoveHealthChecks is called from the manager with the names of the
+	// health checks that are no longer managed.
+	RemoveHealthChecks(context.Context, []string)
+}
+
+// ReadinessProber is an optional interface a [Reporter] may implement if it
+// serves readiness probes to an external observer, such as the kubelet. A
+// manager can use it to tell when the observer has seen the application
+// become unready.
+type ReadinessProber interface {
+	// NotReadyProbes returns the number of consecutive readiness probes
+	// answered as not ready.
+	NotReadyProbes() int
+}
+
+// OverrideReporter is an optional interface a [Reporter] may implement to be
+// told when liveness or readiness is being forced by an operator rather than
+// derived from health checks, for example during maintenance.
+type OverrideReporter interface {
+	// SetProbeOverride is called with the probe name ("liveness" or
+	// "readiness") and the reason for the override, or an empty reason when
+	// the override is lifted.
+	SetProbeOverride(ctx context.Context, probe, reason string)
+}
+
 // Checker performs an individual health check and returns the result
 // to the health manager.
 type Checker interface {
@@ -16,6 +16,7 @@ func TestStatus_String(t *testing.T) {
 		{health.StatusHealthy, "healthy"},
 		{health.StatusDegraded, "degraded"},
 		{health.StatusUnhealthy, "unhealthy"},
+		{health.StatusSkipped, "skipped"},
 		{health.Status(99), "unknown"},
 	}
 	for _, tt := range tests {
@@ -107,6 +108,69 @@ func TestWithDependsOn(t *testing.T) {
 	}
 }
 
+func TestWithFailureThreshold(t *testing.T) {
+	var opts health.AddCheckOptions
+	health.WithFailureThreshold(3)(&opts)
+	if opts.FailureThreshold != 3 {
+		t.Errorf("expected failure threshold 3, got %d", opts.FailureThreshold)
+	}
+}
+
+func TestWithSuccessThreshold(t *testing.T) {
+	var opts health.AddCheckOptions
+	health.WithSuccessThreshold(2)(&opts)
+	if opts.SuccessThreshold != 2 {
+		t.Errorf("expected success threshold 2, got %d", opts.SuccessThreshold)
+	}
+}
+
+func TestWithCheckTimeout(t *testing.T) {
+	var opts health.AddCheckOptions
+	health.WithCheckTimeout(2 * time.Second)(&opts)
+	if opts.Timeout != 2*time.Second {
+		t.Errorf("expected 2s timeout, got %v", opts.Timeout)
+	}
+}
+
+func TestWithJitter(t *testing.T) {
+	var opts health.AddCheckOptions
+	health.WithJitter(0.25)(&opts)
+	if opts.Frequency&health.CheckWithJitter == 0 {
+		t.Error("expected CheckWithJitter to be set")
+	}
+	if opts.Jitter != 0.25 {
+		t.Errorf("expected jitter 0.25, got %v", opts.Jitter)
+	}
+}
+
+func TestWithBackoff(t *testing.T) {
+	var opts health.AddCheckOptions
+	health.WithBackoff(time.Second, time.Minute, 1.5)(&opts)
+	if opts.Frequency&health.CheckWithBackoff == 0 {
+		t.Error("expected CheckWithBackoff to be set")
+	}
+	if opts.BackoffInterval != time.Second || opts.BackoffMaxInterval != time.Minute || opts.BackoffMultiplier != 1.5 {
+		t.Errorf("unexpected backoff settings: %v, %v, %v", opts.BackoffInterval, opts.BackoffMaxInterval, opts.BackoffMultiplier)
+	}
+}
+
+func TestWithFlapDetection(t *testing.T) {
+	var opts health.AddCheckOptions
+	health.WithFlapDetection(time.Minute, 4)(&opts)
+	if opts.FlapWindow != time.Minute || opts.FlapThreshold != 4 {
+		t.Errorf("unexpected flap detection settings: %v, %d", opts.FlapWindow, opts.FlapThreshold)
+	}
+}
+
+func TestWithCheckFrequency_PreservesModifiers(t *testing.T) {
+	var opts health.AddCheckOptions
+	health.WithJitter(0.1)(&opts)
+	health.WithCheckFrequency(health.CheckAtInterval, time.Second, 0)(&opts)
+	if opts.Frequency != health.CheckAtInterval|health.CheckWithJitter {
+		t.Errorf("expected CheckAtInterval|CheckWithJitter, got %v", opts.Frequency)
+	}
+}
+
 func TestDefaultLogger(t *testing.T) {
 	l := health.DefaultLogger()
 	if l == nil {
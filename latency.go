@@ -0,0 +1,60 @@
+package health
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"time"
+)
+
+// LatencyThresholds turn passing results that took too long into degraded or
+// unhealthy ones, based on their Duration. The built-in checkers apply them
+// through their WithLatencyThresholds options; custom checkers can call
+// Apply on their results.
+type LatencyThresholds struct {
+	// Warning is the latency above which a healthy result is reported as
+	// degraded. Zero disables it.
+	Warning time.Duration
+	// Critical is the latency above which a healthy or degraded result is
+	// reported as unhealthy. Zero disables it.
+	Critical time.Duration
+}
+
+// Apply returns hc with its status adjusted for its Duration. Healthy
+// results can become degraded or unhealthy, and degraded results can become
+// unhealthy, keeping their error alongside the latency error. The observed
+// latency and the threshold exceeded are recorded in Metadata under
+// "latency" and "latencyThreshold". hc itself is not modified.
+func (t LatencyThresholds) Apply(hc *CheckResult) *CheckResult {
+	if hc == nil || (hc.Status != StatusHealthy && hc.Status != StatusDegraded) {
+		return hc
+	}
+
+	var threshold time.Duration
+	out := *hc
+	switch {
+	case t.Critical > 0 && hc.Duration > t.Critical:
+		threshold = t.Critical
+		out.Status = StatusUnhealthy
+		out.Error = fmt.Errorf("latency %s exceeded critical threshold %s", hc.Duration, t.Critical)
+		if hc.Error != nil {
+			out.Error = errors.Join(hc.Error, out.Error)
+		}
+		if out.ErrorSince.IsZero() {
+			out.ErrorSince = hc.Timestamp
+		}
+	case hc.Status == StatusHealthy && t.Warning > 0 && hc.Duration > t.Warning:
+		threshold = t.Warning
+		out.Status = StatusDegraded
+	default:
+		return hc
+	}
+
+	out.Metadata = maps.Clone(hc.Metadata)
+	if out.Metadata == nil {
+		out.Metadata = make(map[string]string, 2)
+	}
+	out.Metadata["latency"] = hc.Duration.String()
+	out.Metadata["latencyThreshold"] = threshold.String()
+	return &out
+}
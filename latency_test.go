@@ -0,0 +1,55 @@
+package health_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/schigh/health/v2"
+)
+
+func TestLatencyThresholds_Apply(t *testing.T) {
+	thresholds := health.LatencyThresholds{Warning: 100 * time.Millisecond, Critical: time.Second}
+
+	tests := []struct {
+		name      string
+		result    *health.CheckResult
+		want      health.Status
+		threshold string
+	}{
+		{"fast", &health.CheckResult{Status: health.StatusHealthy, Duration: 50 * time.Millisecond}, health.StatusHealthy, ""},
+		{"warning", &health.CheckResult{Status: health.StatusHealthy, Duration: 200 * time.Millisecond}, health.StatusDegraded, "100ms"},
+		{"critical", &health.CheckResult{Status: health.StatusHealthy, Duration: 2 * time.Second}, health.StatusUnhealthy, "1s"},
+		{"degraded", &health.CheckResult{Status: health.StatusDegraded, Duration: 200 * time.Millisecond}, health.StatusDegraded, ""},
+		{"degraded critical", &health.CheckResult{Status: health.StatusDegraded, Error: errors.New("slow"), Duration: 2 * time.Second}, health.StatusUnhealthy, "1s"},
+		{"already failing", &health.CheckResult{Status: health.StatusUnhealthy, Error: errors.New("down"), Duration: 2 * time.Second}, health.StatusUnhealthy, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := thresholds.Apply(tt.result)
+			if got.Status != tt.want {
+				t.Fatalf("expected %s, got %s", tt.want, got.Status)
+			}
+			if got.Metadata["latencyThreshold"] != tt.threshold {
+				t.Fatalf("expected threshold %q in metadata, got %v", tt.threshold, got.Metadata)
+			}
+			if tt.threshold != "" && got.Metadata["latency"] != tt.result.Duration.String() {
+				t.Fatalf("expected observed latency in metadata, got %v", got.Metadata)
+			}
+			if tt.result.Metadata != nil {
+				t.Fatal("expected the original result to be left untouched")
+			}
+		})
+	}
+
+	if r := (health.LatencyThresholds{}).Apply(&health.CheckResult{Duration: time.Hour}); r.Status != health.StatusHealthy {
+		t.Fatalf("expected zero thresholds to be disabled, got %s", r.Status)
+	}
+	if err := thresholds.Apply(tests[2].result).Error; err == nil || err.Error() != "latency 2s exceeded critical threshold 1s" {
+		t.Fatalf("unexpected critical error: %v", err)
+	}
+	if err := thresholds.Apply(tests[4].result).Error; err == nil || err.Error() != "slow\nlatency 2s exceeded critical threshold 1s" {
+		t.Fatalf("expected degraded error kept alongside the critical error, got %v", err)
+	}
+}
@@ -0,0 +1,166 @@
+package std
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/schigh/health/v2"
+)
+
+// localDependencies returns the entries of a check's DependsOn that name
+// other checks registered with this manager. Other entries, such as service
+// URLs for the discovery protocol, are ignored.
+func (m *Manager) localDependencies(w *wrapper) []string {
+	var deps []string
+	for _, dep := range w.opts.DependsOn {
+		if _, ok := m.checkers.Get(dep); ok {
+			deps = append(deps, dep)
+		}
+	}
+	return deps
+}
+
+// pendingDependency returns the first local dependency of a check that has
+// not reported yet, or an empty string if there is none.
+func (m *Manager) pendingDependency(w *wrapper) string {
+	for _, dep := range m.localDependencies(w) {
+		if _, ok := m.checkResults.Get(dep); !ok {
+			return dep
+		}
+	}
+	return ""
+}
+
+// failingDependency returns the first local dependency of a check whose latest
+// result is unhealthy or skipped, or an empty string if there is none.
+func (m *Manager) failingDependency(w *wrapper) string {
+	for _, dep := range m.localDependencies(w) {
+		r, ok := m.checkResults.Get(dep)
+		if !ok || r.last == nil {
+			continue
+		}
+		if r.last.Status == health.StatusUnhealthy || r.last.Status == health.StatusSkipped {
+			return dep
+		}
+	}
+	return ""
+}
+
+// awaitDependencies blocks until every local dependency of a check has
+// reported at least once, so checks first run in dependency order. Returns
+// false if the context is done first.
+func (m *Manager) awaitDependencies(ctx context.Context, name string, w *wrapper) bool {
+	for {
+		// take the notification channel before looking, so a result that
+		// arrives in between is not missed
+		notify := m.resultNotify()
+		dep := m.pendingDependency(w)
+		if dep == "" {
+			return true
+		}
+		m.Logger.Debug("waiting for dependency", "checker", name, "dependency", dep)
+		select {
+		case <-ctx.Done():
+			return false
+		case <-notify:
+		}
+	}
+}
+
+// resultNotify returns a channel that is closed the next time a check result
+// is processed or a check is removed.
+func (m *Manager) resultNotify() <-chan struct{} {
+	m.notifyMx.Lock()
+	defer m.notifyMx.Unlock()
+
+	if m.notify == nil {
+		m.notify = make(chan struct{})
+	}
+	return m.notify
+}
+
+// notifyResult wakes everything waiting on resultNotify.
+func (m *Manager) notifyResult() {
+	m.notifyMx.Lock()
+	defer m.notifyMx.Unlock()
+
+	if m.notify != nil {
+		close(m.notify)
+		m.notify = nil
+	}
+}
+
+// skippedResult is reported in place of running a check whose dependency is
+// failing.
+func skippedResult(name, dep string) *health.CheckResult {
+	now := time.Now()
+	return &health.CheckResult{
+		Name:      name,
+		Status:    health.StatusSkipped,
+		Error:     fmt.Errorf("skipped because %s failed", dep),
+		Timestamp: now,
+		Metadata:  map[string]string{"skippedBecause": dep},
+	}
+}
+
+// dependencyCycle returns the checks forming a cycle among local dependencies,
+// with the first check repeated at the end, or nil if there is no cycle.
+func dependencyCycle(checkers map[string]wrapper) []string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(checkers))
+	var path []string
+
+	var visit func(name string) []string
+	visit = func(name string) []string {
+		state[name] = visiting
+		path = append(path, name)
+		for _, dep := range checkers[name].opts.DependsOn {
+			if _, ok := checkers[dep]; !ok {
+				continue
+			}
+			switch state[dep] {
+			case visiting:
+				start := slices.Index(path, dep)
+				return append(slices.Clone(path[start:]), dep)
+			case unvisited:
+				if cycle := visit(dep); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = visited
+		return nil
+	}
+
+	names := make([]string, 0, len(checkers))
+	for name := range checkers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		if state[name] == unvisited {
+			if cycle := visit(name); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
+// validateDependencies returns an error if local check dependencies form a
+// cycle.
+func validateDependencies(checkers map[string]wrapper) error {
+	if cycle := dependencyCycle(checkers); cycle != nil {
+		return fmt.Errorf("%w.manager.std: check dependency cycle: %s", health.ErrHealth, strings.Join(cycle, " -> "))
+	}
+	return nil
+}
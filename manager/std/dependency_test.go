@@ -0,0 +1,109 @@
+package std_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/schigh/health/v2"
+	"github.com/schigh/health/v2/manager/std"
+	"github.com/schigh/health/v2/reporter/test"
+)
+
+func TestDependency_SkipsWhilePrerequisiteFails(t *testing.T) {
+	mgr := &std.Manager{}
+	rpt := &test.Reporter{}
+
+	var dbHealthy atomic.Bool
+	var cacheRuns atomic.Int32
+	_ = mgr.AddCheck("db", health.CheckerFunc(func(_ context.Context) *health.CheckResult {
+		if dbHealthy.Load() {
+			return &health.CheckResult{Name: "db", Status: health.StatusHealthy}
+		}
+		return &health.CheckResult{Name: "db", Status: health.StatusUnhealthy, Error: errors.New("connection refused")}
+	}), health.WithCheckFrequency(health.CheckAtInterval, 20*time.Millisecond, 0), health.WithReadinessImpact())
+	_ = mgr.AddCheck("cache", health.CheckerFunc(func(_ context.Context) *health.CheckResult {
+		cacheRuns.Add(1)
+		return &health.CheckResult{Name: "cache", Status: health.StatusHealthy}
+	}), health.WithCheckFrequency(health.CheckAtInterval, 20*time.Millisecond, 0), health.WithDependsOn("db"))
+	_ = mgr.AddReporter("test", rpt)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_ = mgr.Run(ctx)
+
+	waitFor(t, 2*time.Second, func() bool {
+		hc := rpt.Report().HealthChecks["cache"]
+		return hc != nil && hc.Status == health.StatusSkipped
+	})
+	if got := rpt.Report().HealthChecks["cache"].Metadata["skippedBecause"]; got != "db" {
+		t.Fatalf("expected skippedBecause 'db', got %q", got)
+	}
+	if n := cacheRuns.Load(); n != 0 {
+		t.Fatalf("expected cache check not to run while db fails, ran %d times", n)
+	}
+
+	dbHealthy.Store(true)
+	waitFor(t, 2*time.Second, func() bool {
+		hc := rpt.Report().HealthChecks["cache"]
+		return hc != nil && hc.Status == health.StatusHealthy
+	})
+	waitFor(t, 2*time.Second, func() bool {
+		return rpt.Report().IsReady
+	})
+
+	_ = mgr.Stop(ctx)
+}
+
+func TestDependency_RunsAfterPrerequisiteReports(t *testing.T) {
+	mgr := &std.Manager{}
+	rpt := &test.Reporter{}
+
+	var dbRan atomic.Bool
+	var orderOK atomic.Bool
+	_ = mgr.AddCheck("db", health.CheckerFunc(func(_ context.Context) *health.CheckResult {
+		time.Sleep(50 * time.Millisecond)
+		dbRan.Store(true)
+		return &health.CheckResult{Name: "db", Status: health.StatusHealthy}
+	}))
+	_ = mgr.AddCheck("migrations", health.CheckerFunc(func(_ context.Context) *health.CheckResult {
+		orderOK.Store(dbRan.Load())
+		return &health.CheckResult{Name: "migrations", Status: health.StatusHealthy}
+	}), health.WithDependsOn("db"))
+	_ = mgr.AddReporter("test", rpt)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_ = mgr.Run(ctx)
+
+	waitFor(t, 2*time.Second, func() bool {
+		hc := rpt.Report().HealthChecks["migrations"]
+		return hc != nil && hc.Status == health.StatusHealthy
+	})
+	if !orderOK.Load() {
+		t.Fatal("expected migrations to run after db reported")
+	}
+
+	_ = mgr.Stop(ctx)
+}
+
+func TestDependency_CycleRejected(t *testing.T) {
+	mgr := &std.Manager{}
+	rpt := &test.Reporter{}
+
+	healthy := NewMockChecker(&health.CheckResult{Status: health.StatusHealthy})
+	_ = mgr.AddCheck("a", healthy, health.WithDependsOn("b"))
+	_ = mgr.AddCheck("b", healthy, health.WithDependsOn("a"))
+	_ = mgr.AddReporter("test", rpt)
+
+	err := <-mgr.Run(context.Background())
+	if err == nil {
+		t.Fatal("expected dependency cycle error")
+	}
+	if !errors.Is(err, health.ErrHealth) || !strings.Contains(err.Error(), "dependency cycle") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,95 @@
+package std
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/schigh/health/v2"
+)
+
+// DefaultShutdownTimeout is how long, beyond DrainPeriod, a stop sequence
+// without a live context may take; see Manager.ShutdownTimeout.
+const DefaultShutdownTimeout = 10 * time.Second
+
+// drainPollInterval is how often the drain sequence checks whether enough
+// readiness probes have been answered as not ready.
+const drainPollInterval = 50 * time.Millisecond
+
+// AddPreStopHook registers a function to run during Stop, after the drain
+// period and before reporters are stopped. Hooks run in the order they were
+// added, with the Stop context; their errors are returned from Stop.
+func (m *Manager) AddPreStopHook(fn func(context.Context) error) {
+	m.hooksMx.Lock()
+	defer m.hooksMx.Unlock()
+	m.preStop = append(m.preStop, fn)
+}
+
+// drain keeps reporters serving while the service is not ready, so that
+// readiness probes observe the change before the reporters go away. It
+// returns when the drain period has elapsed, when DrainProbes consecutive
+// readiness probes have been answered as not ready, or when the context is
+// done, whichever comes first.
+func (m *Manager) drain(ctx context.Context) {
+	if m.DrainPeriod <= 0 && m.DrainProbes <= 0 {
+		return
+	}
+
+	var deadline <-chan time.Time
+	if m.DrainPeriod > 0 {
+		t := time.NewTimer(m.DrainPeriod)
+		defer t.Stop()
+		deadline = t.C
+	}
+
+	var poll <-chan time.Time
+	if m.DrainProbes > 0 {
+		t := time.NewTicker(drainPollInterval)
+		defer t.Stop()
+		poll = t.C
+	}
+
+	m.Logger.Info("draining before stop", "period", m.DrainPeriod, "probes", m.DrainProbes)
+	for {
+		select {
+		case <-ctx.Done():
+			m.Logger.Warn("drain cut short", "error", ctx.Err())
+			return
+		case <-deadline:
+			return
+		case <-poll:
+			if m.notReadyProbes() >= m.DrainProbes {
+				return
+			}
+		}
+	}
+}
+
+// notReadyProbes returns the highest count of consecutive not-ready
+// readiness probes across reporters implementing [health.ReadinessProber].
+func (m *Manager) notReadyProbes() int {
+	var n int
+	m.reporters.Each(func(_ string, reporter health.Reporter) bool {
+		if p, ok := reporter.(health.ReadinessProber); ok {
+			n = max(n, p.NotReadyProbes())
+		}
+		return true
+	})
+	return n
+}
+
+// runPreStopHooks runs the registered pre-stop hooks in order.
+func (m *Manager) runPreStopHooks(ctx context.Context) error {
+	m.hooksMx.Lock()
+	hooks := append([]func(context.Context) error(nil), m.preStop...)
+	m.hooksMx.Unlock()
+
+	var errs []error
+	for i, fn := range hooks {
+		if err := fn(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("pre-stop hook %d failed: %w", i, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,259 @@
+package std_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/schigh/health/v2"
+	"github.com/schigh/health/v2/manager/std"
+)
+
+// proberReporter is a MockReporter that also answers readiness probes.
+type proberReporter struct {
+	*MockReporter
+	probes atomic.Int32
+}
+
+func (p *proberReporter) NotReadyProbes() int {
+	return int(p.probes.Load())
+}
+
+func TestStop_DrainSequence(t *testing.T) {
+	mgr := &std.Manager{DrainPeriod: 100 * time.Millisecond}
+	rpt := &MockReporter{}
+
+	_ = mgr.AddCheck("db", NewMockChecker(&health.CheckResult{Status: health.StatusHealthy}), health.WithReadinessImpact())
+	_ = mgr.AddReporter("mock", rpt)
+
+	var mu sync.Mutex
+	var steps []string
+	mgr.AddPreStopHook(func(_ context.Context) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if rpt.IsReady() {
+			steps = append(steps, "ready")
+		}
+		if atomic.LoadUint32(&rpt.running) == 1 {
+			steps = append(steps, "serving")
+		}
+		steps = append(steps, "hook")
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_ = mgr.Run(ctx)
+
+	waitFor(t, 2*time.Second, rpt.IsReady)
+
+	start := time.Now()
+	if err := mgr.Stop(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Fatalf("expected Stop to drain for 100ms, took %s", elapsed)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if strings.Join(steps, ",") != "serving,hook" {
+		t.Fatalf("expected hook to run unready while the reporter still serves, got %v", steps)
+	}
+	if atomic.LoadUint32(&rpt.running) != 0 {
+		t.Fatal("expected reporter to be stopped after the hooks")
+	}
+}
+
+func TestStop_DrainEndsAfterProbes(t *testing.T) {
+	mgr := &std.Manager{DrainPeriod: 10 * time.Second, DrainProbes: 3}
+	rpt := &proberReporter{MockReporter: &MockReporter{}}
+
+	_ = mgr.AddCheck("db", NewMockChecker(&health.CheckResult{Status: health.StatusHealthy}), health.WithReadinessImpact())
+	_ = mgr.AddReporter("mock", rpt)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_ = mgr.Run(ctx)
+
+	waitFor(t, 2*time.Second, rpt.IsReady)
+
+	// simulate the kubelet probing /readyz after readiness drops
+	go func() {
+		for rpt.IsReady() {
+			time.Sleep(5 * time.Millisecond)
+		}
+		for rpt.probes.Load() < 3 {
+			rpt.probes.Add(1)
+			time.Sleep(20 * time.Millisecond)
+		}
+	}()
+
+	start := time.Now()
+	if err := mgr.Stop(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("expected drain to end after 3 probes, took %s", elapsed)
+	}
+}
+
+func TestStop_DrainBoundedByContext(t *testing.T) {
+	mgr := &std.Manager{DrainPeriod: 10 * time.Second}
+	rpt := &MockReporter{}
+
+	_ = mgr.AddCheck("db", NewMockChecker(&health.CheckResult{Status: health.StatusHealthy}))
+	_ = mgr.AddReporter("mock", rpt)
+
+	_ = mgr.Run(context.Background())
+	waitFor(t, 2*time.Second, rpt.IsReady)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_ = mgr.Stop(ctx)
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("expected Stop to honor its context, took %s", elapsed)
+	}
+}
+
+func TestStop_PreStopHookError(t *testing.T) {
+	mgr := &std.Manager{}
+	rpt := &MockReporter{}
+
+	_ = mgr.AddCheck("db", NewMockChecker(&health.CheckResult{Status: health.StatusHealthy}))
+	_ = mgr.AddReporter("mock", rpt)
+	mgr.AddPreStopHook(func(_ context.Context) error {
+		return errors.New("flush failed")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_ = mgr.Run(ctx)
+
+	err := mgr.Stop(ctx)
+	if err == nil || !errors.Is(err, health.ErrHealth) || !strings.Contains(err.Error(), "flush failed") {
+		t.Fatalf("expected hook error from Stop, got %v", err)
+	}
+	if atomic.LoadUint32(&rpt.stopCount) != 1 {
+		t.Fatal("expected reporters to be stopped despite the hook error")
+	}
+}
+
+func TestStop_RunContextCancelled(t *testing.T) {
+	mgr := &std.Manager{DrainPeriod: 100 * time.Millisecond}
+	rpt := &MockReporter{}
+
+	_ = mgr.AddCheck("db", NewMockChecker(&health.CheckResult{Status: health.StatusHealthy}), health.WithReadinessImpact())
+	_ = mgr.AddReporter("mock", rpt)
+
+	var hookErr atomic.Value
+	mgr.AddPreStopHook(func(ctx context.Context) error {
+		hookErr.Store(fmt.Sprint(ctx.Err()))
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	_ = mgr.Run(ctx)
+	waitFor(t, 2*time.Second, rpt.IsReady)
+
+	// the signal.NotifyContext pattern: Stop with the cancelled Run context
+	start := time.Now()
+	cancel()
+	if err := mgr.Stop(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Fatalf("expected the drain to run after the Run context was cancelled, took %s", elapsed)
+	}
+	if got := hookErr.Load(); got != "<nil>" {
+		t.Fatalf("expected the pre-stop hook to get a live context, got %v", got)
+	}
+	if atomic.LoadUint32(&rpt.running) != 0 {
+		t.Fatal("expected reporter to be stopped when Stop returns")
+	}
+}
+
+// livenessBlocker is a MockReporter that, once armed, blocks in SetLiveness
+// when liveness recovers, holding the result loop mid-evaluation.
+type livenessBlocker struct {
+	*MockReporter
+	armed   atomic.Bool
+	entered chan struct{}
+	release chan struct{}
+}
+
+func (b *livenessBlocker) SetLiveness(ctx context.Context, live bool) {
+	if live && b.armed.CompareAndSwap(true, false) {
+		close(b.entered)
+		<-b.release
+	}
+	b.MockReporter.SetLiveness(ctx, live)
+}
+
+func TestStop_ReadinessHeldFalseDuringDrain(t *testing.T) {
+	mgr := &std.Manager{DrainPeriod: 100 * time.Millisecond}
+	rpt := &livenessBlocker{
+		MockReporter: &MockReporter{},
+		entered:      make(chan struct{}),
+		release:      make(chan struct{}),
+	}
+
+	var healthy atomic.Bool
+	healthy.Store(true)
+	_ = mgr.AddCheck("db", health.CheckerFunc(func(context.Context) *health.CheckResult {
+		if healthy.Load() {
+			return &health.CheckResult{Status: health.StatusHealthy}
+		}
+		return &health.CheckResult{Status: health.StatusUnhealthy}
+	}), health.WithLivenessImpact(), health.WithReadinessImpact(),
+		health.WithCheckFrequency(health.CheckAtInterval, 5*time.Millisecond, 0))
+	_ = mgr.AddReporter("mock", rpt)
+
+	var (
+		readyAfterDrain atomic.Bool
+		reported        atomic.Int32
+		before          int
+	)
+	mgr.AddPreStopHook(func(context.Context) error {
+		readyAfterDrain.Store(rpt.IsReady())
+		reported.Store(int32(len(rpt.History()) - before))
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_ = mgr.Run(ctx)
+	waitFor(t, 2*time.Second, rpt.IsReady)
+
+	healthy.Store(false)
+	waitFor(t, 2*time.Second, func() bool { return !rpt.IsReady() })
+
+	// the loop evaluates the recovery, read readiness as false, and is held
+	// before setting it true while Stop begins
+	rpt.armed.Store(true)
+	healthy.Store(true)
+	<-rpt.entered
+
+	before = len(rpt.History())
+	stopped := make(chan error, 1)
+	go func() { stopped <- mgr.Stop(ctx) }()
+	time.Sleep(20 * time.Millisecond)
+	close(rpt.release)
+	if err := <-stopped; err != nil {
+		t.Fatal(err)
+	}
+
+	if readyAfterDrain.Load() {
+		t.Fatal("expected readiness to stay false for the whole drain")
+	}
+	if reported.Load() == 0 {
+		t.Fatal("expected check results to keep reaching reporters during the drain")
+	}
+}
@@ -0,0 +1,157 @@
+package std
+
+import (
+	"sync"
+	"time"
+
+	"github.com/schigh/health/v2"
+)
+
+// DefaultEventBuffer is the channel capacity used by Subscribe when a
+// non-positive buffer size is given.
+const DefaultEventBuffer = 16
+
+// EventKind identifies what changed in an [Event].
+type EventKind int
+
+const (
+	// EventLiveness is emitted when liveness flips.
+	EventLiveness EventKind = iota + 1
+
+	// EventReadiness is emitted when readiness flips.
+	EventReadiness
+
+	// EventStartup is emitted when startup completes, or is reset.
+	EventStartup
+
+	// EventCheckStatus is emitted when a check reports its first result or
+	// its status changes.
+	EventCheckStatus
+)
+
+// String returns the lowercase string representation of an EventKind.
+func (k EventKind) String() string {
+	switch k {
+	case EventLiveness:
+		return "liveness"
+	case EventReadiness:
+		return "readiness"
+	case EventStartup:
+		return "startup"
+	case EventCheckStatus:
+		return "check"
+	default:
+		return "unknown"
+	}
+}
+
+// Event describes a state transition in a [Manager].
+type Event struct {
+	Kind EventKind
+	Time time.Time
+
+	// Value is the new state for liveness, readiness and startup events.
+	Value bool
+
+	// Check, From, To and Error describe check status events. Initial is
+	// true for a check's first result, in which case From is meaningless.
+	Check   string
+	From    health.Status
+	To      health.Status
+	Error   error
+	Initial bool
+}
+
+// subscriber is a single Subscribe channel.
+type subscriber struct {
+	ch      chan Event
+	dropped uint64
+}
+
+// subscribers tracks the event subscribers of a manager.
+type subscribers struct {
+	mu   sync.Mutex
+	next int
+	subs map[int]*subscriber
+}
+
+// Subscribe returns a channel of state transition events and a function that
+// cancels the subscription and closes the channel. Events are delivered
+// without blocking: if the channel's buffer is full, the event is dropped
+// for that subscriber, so a slow subscriber can't stall the manager. A
+// non-positive buffer uses DefaultEventBuffer.
+func (m *Manager) Subscribe(buffer int) (<-chan Event, func()) {
+	if buffer <= 0 {
+		buffer = DefaultEventBuffer
+	}
+
+	m.events.mu.Lock()
+	defer m.events.mu.Unlock()
+
+	if m.events.subs == nil {
+		m.events.subs = make(map[int]*subscriber)
+	}
+	id := m.events.next
+	m.events.next++
+	s := &subscriber{ch: make(chan Event, buffer)}
+	m.events.subs[id] = s
+
+	var once sync.Once
+	return s.ch, func() {
+		once.Do(func() {
+			m.events.mu.Lock()
+			defer m.events.mu.Unlock()
+			delete(m.events.subs, id)
+			close(s.ch)
+		})
+	}
+}
+
+// OnTransition registers a callback for state transition events and returns
+// a function that unregisters it. Callbacks run on their own goroutine, one
+// event at a time, and are subject to the same delivery rules as Subscribe.
+func (m *Manager) OnTransition(fn func(Event)) func() {
+	ch, cancel := m.Subscribe(0)
+	go func() {
+		for e := range ch {
+			fn(e)
+		}
+	}()
+	return cancel
+}
+
+// emit delivers an event to every subscriber without blocking.
+func (m *Manager) emit(e Event) {
+	e.Time = time.Now()
+
+	m.events.mu.Lock()
+	defer m.events.mu.Unlock()
+
+	for _, s := range m.events.subs {
+		select {
+		case s.ch <- e:
+		default:
+			s.dropped++
+			m.Logger.Debug("dropped health event for slow subscriber", "event", e.Kind.String(), "dropped", s.dropped)
+		}
+	}
+}
+
+// emitCheckStatus emits a check status event if the status of a check has
+// changed from its previous result.
+func (m *Manager) emitCheckStatus(prev, next *health.CheckResult) {
+	if prev != nil && prev.Status == next.Status {
+		return
+	}
+	e := Event{
+		Kind:    EventCheckStatus,
+		Check:   next.Name,
+		To:      next.Status,
+		Error:   next.Error,
+		Initial: prev == nil,
+	}
+	if prev != nil {
+		e.From = prev.Status
+	}
+	m.emit(e)
+}
@@ -0,0 +1,127 @@
+package std_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/schigh/health/v2"
+	"github.com/schigh/health/v2/manager/std"
+	"github.com/schigh/health/v2/reporter/test"
+)
+
+func TestSubscribe(t *testing.T) {
+	mgr := &std.Manager{}
+	rpt := &test.Reporter{}
+
+	var failing atomic.Bool
+	_ = mgr.AddCheck("db", health.CheckerFunc(func(_ context.Context) *health.CheckResult {
+		if failing.Load() {
+			return &health.CheckResult{Name: "db", Status: health.StatusUnhealthy, Error: errors.New("refused")}
+		}
+		return &health.CheckResult{Name: "db", Status: health.StatusHealthy}
+	}), health.WithCheckFrequency(health.CheckAtInterval, 20*time.Millisecond, 0), health.WithReadinessImpact())
+	_ = mgr.AddReporter("test", rpt)
+
+	events, cancelSub := mgr.Subscribe(64)
+	defer cancelSub()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_ = mgr.Run(ctx)
+
+	next := func(match func(std.Event) bool) std.Event {
+		t.Helper()
+		timeout := time.After(2 * time.Second)
+		for {
+			select {
+			case e := <-events:
+				if match(e) {
+					return e
+				}
+			case <-timeout:
+				t.Fatal("timed out waiting for event")
+			}
+		}
+	}
+
+	e := next(func(e std.Event) bool { return e.Kind == std.EventCheckStatus })
+	if !e.Initial || e.Check != "db" || e.To != health.StatusHealthy {
+		t.Fatalf("unexpected initial check event: %+v", e)
+	}
+	next(func(e std.Event) bool { return e.Kind == std.EventReadiness && e.Value })
+
+	failing.Store(true)
+	e = next(func(e std.Event) bool { return e.Kind == std.EventCheckStatus })
+	if e.Initial || e.From != health.StatusHealthy || e.To != health.StatusUnhealthy || e.Error == nil {
+		t.Fatalf("unexpected check transition event: %+v", e)
+	}
+	next(func(e std.Event) bool { return e.Kind == std.EventReadiness && !e.Value })
+
+	_ = mgr.Stop(ctx)
+}
+
+func TestSubscribe_SlowSubscriberDoesNotBlock(t *testing.T) {
+	mgr := &std.Manager{}
+	rpt := &test.Reporter{}
+
+	var n atomic.Int32
+	_ = mgr.AddCheck("flappy", health.CheckerFunc(func(_ context.Context) *health.CheckResult {
+		if n.Add(1)%2 == 0 {
+			return &health.CheckResult{Name: "flappy", Status: health.StatusUnhealthy}
+		}
+		return &health.CheckResult{Name: "flappy", Status: health.StatusHealthy}
+	}), health.WithCheckFrequency(health.CheckAtInterval, 5*time.Millisecond, 0))
+	_ = mgr.AddReporter("test", rpt)
+
+	// never read
+	_, cancelSub := mgr.Subscribe(1)
+	defer cancelSub()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_ = mgr.Run(ctx)
+
+	waitFor(t, 2*time.Second, func() bool {
+		return rpt.Report().NumHealthCheckUpdates >= 10
+	})
+
+	_ = mgr.Stop(ctx)
+}
+
+func TestOnTransition(t *testing.T) {
+	mgr := &std.Manager{}
+	rpt := &test.Reporter{}
+
+	_ = mgr.AddCheck("db", NewMockChecker(&health.CheckResult{Status: health.StatusHealthy}), health.WithLivenessImpact())
+	_ = mgr.AddReporter("test", rpt)
+
+	var mu sync.Mutex
+	var kinds []std.EventKind
+	stop := mgr.OnTransition(func(e std.Event) {
+		mu.Lock()
+		defer mu.Unlock()
+		kinds = append(kinds, e.Kind)
+	})
+	defer stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_ = mgr.Run(ctx)
+
+	waitFor(t, 2*time.Second, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		var live, ready bool
+		for _, k := range kinds {
+			live = live || k == std.EventLiveness
+			ready = ready || k == std.EventReadiness
+		}
+		return live && ready
+	})
+
+	_ = mgr.Stop(ctx)
+}
@@ -0,0 +1,158 @@
+package std
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/schigh/health/v2"
+)
+
+// GroupPolicy decides how many of the checks in a group (see
+// [health.WithGroup]) must be passing for the group to pass. Healthy and
+// degraded checks are passing; unhealthy and skipped checks are not. A group
+// that fails its policy fails readiness if any of its checks affect
+// readiness, and liveness if any of its checks affect liveness. Groups
+// without a policy are not aggregated: each check affects the probes on its
+// own.
+type GroupPolicy struct {
+	// MinPassing is the number of checks in the group that must be passing.
+	// Zero means all of them.
+	MinPassing int
+}
+
+// RequireAll returns a policy that passes only if every check in the group
+// is passing.
+func RequireAll() GroupPolicy {
+	return GroupPolicy{}
+}
+
+// RequireAny returns a policy that passes if at least one check in the group
+// is passing.
+func RequireAny() GroupPolicy {
+	return GroupPolicy{MinPassing: 1}
+}
+
+// RequireQuorum returns a policy that passes if at least n checks in the
+// group are passing.
+func RequireQuorum(n int) GroupPolicy {
+	return GroupPolicy{MinPassing: n}
+}
+
+// String returns a short description of the policy.
+func (p GroupPolicy) String() string {
+	switch {
+	case p.MinPassing <= 0:
+		return "all"
+	case p.MinPassing == 1:
+		return "any"
+	default:
+		return "at least " + strconv.Itoa(p.MinPassing)
+	}
+}
+
+// required returns the number of passing checks needed out of total.
+func (p GroupPolicy) required(total int) int {
+	if p.MinPassing <= 0 {
+		return total
+	}
+	return p.MinPassing
+}
+
+// groupTally accumulates the results of the checks in one group.
+type groupTally struct {
+	policy       GroupPolicy
+	total        int
+	passing      int
+	affectsLive  bool
+	affectsReady bool
+}
+
+func (t *groupTally) add(r result) {
+	t.total++
+	if r.last == nil {
+		return
+	}
+	if r.last.Status == health.StatusHealthy || r.last.Status == health.StatusDegraded {
+		t.passing++
+	}
+	t.affectsLive = t.affectsLive || r.last.AffectsLiveness
+	t.affectsReady = t.affectsReady || r.last.AffectsReadiness || r.last.AffectsLiveness
+}
+
+func (t *groupTally) passed() bool {
+	return t.passing >= t.policy.required(t.total)
+}
+
+// groupPolicy returns the group a check belongs to and that group's policy,
+// if it has one.
+func (m *Manager) groupPolicy(name string) (string, GroupPolicy, bool) {
+	w, ok := m.checkers.Get(name)
+	if !ok || w.opts.Group == "" {
+		return "", GroupPolicy{}, false
+	}
+	policy, ok := m.GroupPolicies[w.opts.Group]
+	return w.opts.Group, policy, ok
+}
+
+// tallyGroup tallies the latest results of the checks in a group. If current
+// is non-nil, it is used in place of the stored result for that check.
+func (m *Manager) tallyGroup(group string, policy GroupPolicy, current *result) *groupTally {
+	t := groupTally{policy: policy}
+	m.checkResults.Each(func(name string, value result) bool {
+		if current != nil && current.last != nil && current.last.Name == name {
+			return true
+		}
+		if g, _, ok := m.groupPolicy(name); ok && g == group {
+			t.add(value)
+		}
+		return true
+	})
+	if current != nil {
+		t.add(*current)
+	}
+	return &t
+}
+
+// groupResults annotates the latest result of every check in a group with
+// the group's policy and tally, stores the annotated results and returns
+// them for relaying to reporters. If current is non-nil, it is the result
+// being processed for one of the group's checks and is annotated in place.
+func (m *Manager) groupResults(group string, policy GroupPolicy, current *result) map[string]*health.CheckResult {
+	t := m.tallyGroup(group, policy, current)
+	kv := []string{
+		"groupPolicy", policy.String(),
+		"groupPassing", fmt.Sprintf("%d of %d", t.passing, t.total),
+	}
+
+	hcs := make(map[string]*health.CheckResult)
+	if current != nil && current.last != nil {
+		current.last = withMetadata(current.last, kv...)
+		hcs[current.last.Name] = current.last
+	}
+	for name, value := range m.checkResults.Value() {
+		if _, ok := hcs[name]; ok || value.last == nil {
+			continue
+		}
+		if g, _, ok := m.groupPolicy(name); !ok || g != group {
+			continue
+		}
+		value.last = withMetadata(value.last, kv...)
+		m.checkResults.Set(name, value)
+		hcs[name] = value.last
+	}
+	return hcs
+}
+
+// relayGroup re-annotates and relays the results of every check in a group,
+// for example after one of them has been removed.
+func (m *Manager) relayGroup(ctx context.Context, group string, policy GroupPolicy) {
+	hcs := m.groupResults(group, policy, nil)
+	if len(hcs) == 0 {
+		return
+	}
+	m.reporters.Each(func(_ string, reporter health.Reporter) bool {
+		reporter.UpdateHealthChecks(ctx, hcs)
+		return true
+	})
+}
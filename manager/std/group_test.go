@@ -0,0 +1,138 @@
+package std_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/schigh/health/v2"
+	"github.com/schigh/health/v2/manager/std"
+	"github.com/schigh/health/v2/reporter/test"
+)
+
+// toggleChecker is healthy until its flag is set.
+func toggleChecker(name string, failing *atomic.Bool) health.Checker {
+	return health.CheckerFunc(func(_ context.Context) *health.CheckResult {
+		if failing.Load() {
+			return &health.CheckResult{Name: name, Status: health.StatusUnhealthy, Error: errors.New("down")}
+		}
+		return &health.CheckResult{Name: name, Status: health.StatusHealthy}
+	})
+}
+
+func TestGroupPolicy_Quorum(t *testing.T) {
+	mgr := &std.Manager{
+		GroupPolicies: map[string]std.GroupPolicy{"cache": std.RequireQuorum(2)},
+	}
+	rpt := &test.Reporter{}
+
+	var failing [3]atomic.Bool
+	for i, name := range []string{"redis-0", "redis-1", "redis-2"} {
+		_ = mgr.AddCheck(name, toggleChecker(name, &failing[i]),
+			health.WithCheckFrequency(health.CheckAtInterval, 20*time.Millisecond, 0),
+			health.WithReadinessImpact(),
+			health.WithGroup("cache"),
+		)
+	}
+	_ = mgr.AddReporter("test", rpt)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_ = mgr.Run(ctx)
+
+	waitFor(t, 2*time.Second, func() bool {
+		return rpt.Report().IsReady
+	})
+
+	failing[0].Store(true)
+	waitFor(t, 2*time.Second, func() bool {
+		hc := rpt.Report().HealthChecks["redis-0"]
+		return hc != nil && hc.Status == health.StatusUnhealthy
+	})
+	waitFor(t, 2*time.Second, func() bool {
+		hc := rpt.Report().HealthChecks["redis-1"]
+		return hc != nil && hc.Metadata["groupPassing"] == "2 of 3"
+	})
+	if !rpt.Report().IsReady {
+		t.Fatal("expected readiness to hold with 2 of 3 cache replicas passing")
+	}
+	if got := rpt.Report().HealthChecks["redis-1"].Metadata["groupPolicy"]; got != "at least 2" {
+		t.Fatalf("expected groupPolicy 'at least 2', got %q", got)
+	}
+
+	failing[1].Store(true)
+	waitFor(t, 2*time.Second, func() bool {
+		return !rpt.Report().IsReady
+	})
+
+	failing[0].Store(false)
+	waitFor(t, 2*time.Second, func() bool {
+		return rpt.Report().IsReady
+	})
+
+	_ = mgr.Stop(ctx)
+}
+
+func TestGroupPolicy_Any(t *testing.T) {
+	mgr := &std.Manager{
+		GroupPolicies: map[string]std.GroupPolicy{"payments": std.RequireAny()},
+	}
+	rpt := &test.Reporter{}
+
+	var stripe, adyen atomic.Bool
+	stripe.Store(true)
+	_ = mgr.AddCheck("stripe", toggleChecker("stripe", &stripe),
+		health.WithCheckFrequency(health.CheckAtInterval, 20*time.Millisecond, 0),
+		health.WithReadinessImpact(), health.WithGroup("payments"))
+	_ = mgr.AddCheck("adyen", toggleChecker("adyen", &adyen),
+		health.WithCheckFrequency(health.CheckAtInterval, 20*time.Millisecond, 0),
+		health.WithReadinessImpact(), health.WithGroup("payments"))
+	_ = mgr.AddReporter("test", rpt)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_ = mgr.Run(ctx)
+
+	waitFor(t, 2*time.Second, func() bool {
+		return rpt.Report().IsReady
+	})
+
+	adyen.Store(true)
+	waitFor(t, 2*time.Second, func() bool {
+		return !rpt.Report().IsReady
+	})
+
+	_ = mgr.Stop(ctx)
+}
+
+func TestGroupPolicy_UngroupedChecksStillFail(t *testing.T) {
+	mgr := &std.Manager{
+		GroupPolicies: map[string]std.GroupPolicy{"cache": std.RequireAny()},
+	}
+	rpt := &test.Reporter{}
+
+	var db atomic.Bool
+	_ = mgr.AddCheck("db", toggleChecker("db", &db),
+		health.WithCheckFrequency(health.CheckAtInterval, 20*time.Millisecond, 0),
+		health.WithReadinessImpact())
+	_ = mgr.AddCheck("redis", toggleChecker("redis", new(atomic.Bool)),
+		health.WithReadinessImpact(), health.WithGroup("cache"))
+	_ = mgr.AddReporter("test", rpt)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_ = mgr.Run(ctx)
+
+	waitFor(t, 2*time.Second, func() bool {
+		return rpt.Report().IsReady
+	})
+
+	db.Store(true)
+	waitFor(t, 2*time.Second, func() bool {
+		return !rpt.Report().IsReady
+	})
+
+	_ = mgr.Stop(ctx)
+}
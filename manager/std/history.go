@@ -0,0 +1,100 @@
+package std
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/schigh/health/v2"
+)
+
+// DefaultHistorySize is the number of executions recorded per check when
+// Manager.HistorySize is not set.
+const DefaultHistorySize = 20
+
+func (m *Manager) historySize() int {
+	if m.HistorySize > 0 {
+		return m.HistorySize
+	}
+	return DefaultHistorySize
+}
+
+// recordHistory appends an execution to a check's history, dropping the
+// oldest executions beyond size. Skipped results are not executions and
+// leave the history unchanged.
+func recordHistory(prev []health.CheckExecution, hc *health.CheckResult, size int) []health.CheckExecution {
+	if hc.Status == health.StatusSkipped {
+		return prev
+	}
+
+	ts := hc.Timestamp
+	if ts.IsZero() {
+		ts = time.Now()
+	}
+
+	// always copy so results already handed to reporters keep their view
+	start := max(len(prev)-size+1, 0)
+	out := make([]health.CheckExecution, 0, len(prev)-start+1)
+	out = append(out, prev[start:]...)
+	return append(out, health.CheckExecution{
+		Status:    hc.Status,
+		Duration:  hc.Duration,
+		Error:     hc.Error,
+		Timestamp: ts,
+	})
+}
+
+// summarizeHistory computes the uptime ratio and flap count of the
+// executions within window of the latest one. A zero window covers them all.
+func summarizeHistory(execs []health.CheckExecution, window time.Duration) *health.CheckHistory {
+	h := health.CheckHistory{Executions: execs}
+	if len(execs) == 0 {
+		return &h
+	}
+
+	first := 0
+	if window > 0 {
+		cutoff := execs[len(execs)-1].Timestamp.Add(-window)
+		for first < len(execs)-1 && execs[first].Timestamp.Before(cutoff) {
+			first++
+		}
+	}
+
+	var up int
+	for i := first; i < len(execs); i++ {
+		if passing(execs[i].Status) {
+			up++
+		}
+		if i > first && passing(execs[i].Status) != passing(execs[i-1].Status) {
+			h.Flaps++
+		}
+	}
+	h.Uptime = float64(up) / float64(len(execs)-first)
+
+	return &h
+}
+
+// passing reports whether a status counts as passing for history purposes.
+func passing(s health.Status) bool {
+	return s == health.StatusHealthy || s == health.StatusDegraded
+}
+
+// applyHistory attaches the history summary to a check result and, if flap
+// detection is enabled and the check is flapping, reports an otherwise
+// healthy check as degraded.
+func applyHistory(hc *health.CheckResult, execs []health.CheckExecution, opts *health.AddCheckOptions) *health.CheckResult {
+	h := summarizeHistory(execs, opts.FlapWindow)
+
+	if opts.FlapThreshold > 0 && h.Flaps > opts.FlapThreshold && hc.Status == health.StatusHealthy {
+		hc = withMetadata(hc,
+			"flaps", strconv.Itoa(h.Flaps),
+			"flapThreshold", strconv.Itoa(opts.FlapThreshold),
+		)
+		hc.Status = health.StatusDegraded
+	} else {
+		out := *hc
+		hc = &out
+	}
+
+	hc.History = h
+	return hc
+}
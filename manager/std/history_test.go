@@ -0,0 +1,87 @@
+package std_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/schigh/health/v2"
+	"github.com/schigh/health/v2/manager/std"
+	"github.com/schigh/health/v2/reporter/test"
+)
+
+func TestHistory_Bounded(t *testing.T) {
+	mgr := &std.Manager{HistorySize: 3}
+	rpt := &test.Reporter{}
+
+	var n atomic.Int32
+	_ = mgr.AddCheck("api", health.CheckerFunc(func(_ context.Context) *health.CheckResult {
+		if n.Add(1)%2 == 0 {
+			return &health.CheckResult{Name: "api", Status: health.StatusUnhealthy, Error: errors.New("500")}
+		}
+		return &health.CheckResult{Name: "api", Status: health.StatusHealthy}
+	}), health.WithCheckFrequency(health.CheckAtInterval, 10*time.Millisecond, 0))
+	_ = mgr.AddReporter("test", rpt)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_ = mgr.Run(ctx)
+
+	waitFor(t, 2*time.Second, func() bool {
+		return n.Load() >= 5
+	})
+
+	hc := rpt.Report().HealthChecks["api"]
+	if hc.History == nil {
+		t.Fatal("expected history on check result")
+	}
+	if got := len(hc.History.Executions); got != 3 {
+		t.Fatalf("expected 3 recorded executions, got %d", got)
+	}
+	if hc.History.Flaps != 2 {
+		t.Fatalf("expected 2 flaps in an alternating history of 3, got %d", hc.History.Flaps)
+	}
+	if hc.History.Uptime <= 0 || hc.History.Uptime >= 1 {
+		t.Fatalf("expected partial uptime, got %v", hc.History.Uptime)
+	}
+
+	_ = mgr.Stop(ctx)
+}
+
+func TestHistory_FlapDetection(t *testing.T) {
+	mgr := &std.Manager{}
+	rpt := &test.Reporter{}
+
+	var n atomic.Int32
+	var settle atomic.Bool
+	_ = mgr.AddCheck("api", health.CheckerFunc(func(_ context.Context) *health.CheckResult {
+		if !settle.Load() && n.Add(1)%2 == 0 {
+			return &health.CheckResult{Name: "api", Status: health.StatusUnhealthy, Error: errors.New("500")}
+		}
+		return &health.CheckResult{Name: "api", Status: health.StatusHealthy}
+	}),
+		health.WithCheckFrequency(health.CheckAtInterval, 10*time.Millisecond, 0),
+		health.WithFlapDetection(100*time.Millisecond, 3),
+	)
+	_ = mgr.AddReporter("test", rpt)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_ = mgr.Run(ctx)
+
+	waitFor(t, 2*time.Second, func() bool {
+		hc := rpt.Report().HealthChecks["api"]
+		return hc != nil && hc.Status == health.StatusDegraded && hc.Metadata["flapThreshold"] == "3"
+	})
+
+	// once the flaps age out of the window, the check is healthy again
+	settle.Store(true)
+	waitFor(t, 2*time.Second, func() bool {
+		hc := rpt.Report().HealthChecks["api"]
+		return hc != nil && hc.Status == health.StatusHealthy
+	})
+
+	_ = mgr.Stop(ctx)
+}
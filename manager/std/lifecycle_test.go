@@ -0,0 +1,112 @@
+package std_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/schigh/health/v2"
+	"github.com/schigh/health/v2/manager/std"
+	"github.com/schigh/health/v2/reporter/test"
+)
+
+func TestStop_DuringDelay(t *testing.T) {
+	mgr := &std.Manager{}
+	rpt := &test.Reporter{}
+
+	_ = mgr.AddCheck("slow-start", NewMockChecker(&health.CheckResult{Status: health.StatusHealthy}),
+		health.WithCheckFrequency(health.CheckAtInterval|health.CheckAfter, time.Second, time.Hour))
+	_ = mgr.AddCheck("once", NewMockChecker(&health.CheckResult{Status: health.StatusHealthy}),
+		health.WithCheckFrequency(health.CheckOnce|health.CheckAfter, 0, time.Hour))
+	_ = mgr.AddReporter("test", rpt)
+
+	_ = mgr.Run(context.Background())
+
+	// Stop waits for check goroutines; a delay that ignored cancellation
+	// would make it time out
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := mgr.Stop(ctx); err != nil {
+		t.Fatalf("expected Stop to end delayed checks, got %v", err)
+	}
+}
+
+func TestStop_CancelsChecks(t *testing.T) {
+	mgr := &std.Manager{}
+	rpt := &test.Reporter{}
+
+	var runs atomic.Int32
+	_ = mgr.AddCheck("ticker", health.CheckerFunc(func(_ context.Context) *health.CheckResult {
+		runs.Add(1)
+		return &health.CheckResult{Status: health.StatusHealthy}
+	}), health.WithCheckFrequency(health.CheckAtInterval, 5*time.Millisecond, 0))
+	_ = mgr.AddReporter("test", rpt)
+
+	// the Run context is never cancelled
+	_ = mgr.Run(context.Background())
+	waitFor(t, 2*time.Second, func() bool {
+		return runs.Load() >= 3
+	})
+
+	if err := mgr.Stop(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	stopped := runs.Load()
+	time.Sleep(50 * time.Millisecond)
+	if n := runs.Load(); n != stopped {
+		t.Fatalf("expected no check executions after Stop, got %d more", n-stopped)
+	}
+}
+
+func TestRun_AfterStop(t *testing.T) {
+	mgr := &std.Manager{}
+	rpt := &MockReporter{}
+
+	// unhealthy during the first run, healthy during the second
+	var secondRun atomic.Bool
+	_ = mgr.AddCheck("db", health.CheckerFunc(func(context.Context) *health.CheckResult {
+		if secondRun.Load() {
+			return &health.CheckResult{Status: health.StatusHealthy}
+		}
+		return &health.CheckResult{Status: health.StatusUnhealthy}
+	}),
+		health.WithCheckFrequency(health.CheckAtInterval, time.Millisecond, 0),
+		health.WithReadinessImpact())
+	_ = mgr.AddReporter("test", rpt)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_ = mgr.Run(ctx)
+	waitFor(t, 2*time.Second, func() bool {
+		return len(rpt.History()) >= 3
+	})
+
+	if err := mgr.Stop(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if rpt.IsReady() {
+		t.Fatal("expected not ready after Stop")
+	}
+
+	secondRun.Store(true)
+	seen := len(rpt.History())
+	_ = mgr.Run(ctx)
+	waitFor(t, 2*time.Second, func() bool {
+		return rpt.IsReady()
+	})
+	if s := mgr.Snapshot(); !s.Running || s.Checks["db"] == nil {
+		t.Fatalf("expected second run to report checks, got %+v", s)
+	}
+	if err := mgr.Stop(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// results left over from the first run are not processed by the second
+	for _, hc := range rpt.History()[seen:] {
+		if hc.Status != health.StatusHealthy {
+			t.Fatalf("expected only second-run results after restart, got %s", hc.Status)
+		}
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -15,12 +17,39 @@ import (
 type wrapper struct {
 	opts    health.AddCheckOptions
 	checker health.Checker
+	cancel  context.CancelFunc
+
+	// inflight holds the start time (unix nanos) of an execution that is
+	// still running under a check timeout, or zero if there is none.
+	inflight *atomic.Int64
+
+	// gen identifies this dispatch of the check, so that results sent by a
+	// check since replaced or restarted under the same name are dropped.
+	gen uint64
+}
+
+// checkMessage is a check result on its way to the result loop, tagged with
+// the generation of the check that produced it.
+type checkMessage struct {
+	gen uint64
+	hc  *health.CheckResult
 }
 
 // result helps us keep a tally of the checks.
 type result struct {
 	cancelLive  bool
 	cancelReady bool
+	failing     bool
+	tripped     bool
+	failures    int
+	successes   int
+	last        *health.CheckResult
+	history     []health.CheckExecution
+
+	// actual is the latest result before any override is applied, and
+	// actualFailing whether its raw status was failing.
+	actual        *health.CheckResult
+	actualFailing bool
 }
 
 // Manager is the standard manager for application health checks.
@@ -28,7 +57,7 @@ type Manager struct {
 	reporters    syncmap.Map[string, health.Reporter]
 	checkers     syncmap.Map[string, wrapper]
 	checkResults syncmap.Map[string, result]
-	checkFunnel  chan *health.CheckResult
+	checkFunnel  chan checkMessage
 	errChan      chan error
 	runningPtr   uint32
 	livePtr      uint32
@@ -38,6 +67,86 @@ type Manager struct {
 	initialReady uint32
 	startupDone  uint32
 
+	// checkGen numbers each dispatch of a check; see wrapper.gen.
+	checkGen uint64
+
+	// stateMx serializes result processing with changes to the set of checks
+	// and reporters on a running manager.
+	stateMx sync.Mutex
+
+	// runCtx is cancelled by Stop, ending every check goroutine and the
+	// result loop of the current run. checksWG tracks the check goroutines
+	// and loopDone is closed when the result loop exits.
+	runCtx    context.Context
+	runCancel context.CancelFunc
+	checksWG  sync.WaitGroup
+	loopDone  chan struct{}
+
+	// notify is closed and replaced each time a result is processed; see
+	// resultNotify.
+	notifyMx sync.Mutex
+	notify   chan struct{}
+
+	events subscribers
+
+	// CheckTimeout is the default deadline for each check execution. Checks
+	// added with [health.WithCheckTimeout] use their own value instead. Zero
+	// means checks are not given a deadline by the manager.
+	CheckTimeout time.Duration
+
+	// DrainPeriod is how long Stop keeps reporters serving after readiness
+	// is set false, so that readiness probes observe it before the reporters
+	// go away. DrainProbes ends the drain early once that many consecutive
+	// readiness probes have been answered as not ready by a reporter
+	// implementing [health.ReadinessProber]. Zero values disable each.
+	DrainPeriod time.Duration
+	DrainProbes int
+
+	// ShutdownTimeout bounds the stop sequence when it runs without a live
+	// context: when the Run context is cancelled, or when Stop is called with
+	// a context that is already done. Zero means DrainPeriod plus
+	// DefaultShutdownTimeout.
+	ShutdownTimeout time.Duration
+
+	// MaxConcurrentChecks caps how many checks execute at once. Checks that
+	// affect startup run first, then liveness, then readiness, then the rest;
+	// the time each execution waited is recorded in its Metadata under
+	// "queueWait". A check abandoned after its timeout gives up its slot.
+	// Zero means no limit. Read when the manager is run.
+	MaxConcurrentChecks int
+	pool                *workerPool
+
+	// StartupDeadline bounds how long startup may take. If the checks added
+	// with [health.WithStartupImpact] have not all passed within it, a fatal
+	// error is sent on the channel returned by Run, and, if
+	// StartupDeadlineFailsLiveness is set, liveness is set false so the
+	// orchestrator restarts the service. Zero means no deadline.
+	StartupDeadline              time.Duration
+	StartupDeadlineFailsLiveness bool
+
+	// LivenessGracePeriod is how long after startup completes that liveness
+	// failures are tolerated. Zero means none.
+	LivenessGracePeriod time.Duration
+	startupAt           time.Time
+
+	hooksMx sync.Mutex
+	preStop []func(context.Context) error
+
+	// checkOverrides and probeOverrides are guarded by stateMx.
+	checkOverrides map[string]*override
+	probeOverrides map[string]*override
+
+	// GroupPolicies sets how the checks in each named group (see
+	// [health.WithGroup]) are aggregated into liveness and readiness. Groups
+	// without a policy don't aggregate; any failing check affects the probes
+	// on its own. Must be set before the manager is run.
+	GroupPolicies map[string]GroupPolicy
+
+	// HistorySize is the number of executions recorded for each check and
+	// summarized in [health.CheckResult.History]. Zero means
+	// DefaultHistorySize.
+	HistorySize int
+
 	Logger health.Logger
 }
 
@@ -49,15 +158,19 @@ func (m *Manager) isReady() bool {
 	return atomic.LoadUint32(&m.readyPtr) == 1
 }
 
+func (m *Manager) isStartup() bool {
+	return atomic.LoadUint32(&m.startupPtr) == 1
+}
+
 func (m *Manager) running() bool {
 	return atomic.LoadUint32(&m.runningPtr) == 1
 }
 
+// AddCheck adds a named health check to the manager. If the manager is
+// already running, the check is dispatched immediately and readiness is not
+// re-evaluated until it has reported at least once. A running check with the
+// same name is stopped and replaced.
 func (m *Manager) AddCheck(name string, checker health.Checker, opts ...health.AddCheckOption) error {
-	if m.running() {
-		return fmt.Errorf("%w.manager.std: cannot add a health check to a running health instance", health.ErrHealth)
-	}
-
 	o := health.AddCheckOptions{}
 	for i := range opts {
 		opts[i](&o)
@@ -68,29 +181,100 @@ func (m *Manager) AddCheck(name string, checker health.Checker, opts ...health.A
 		o.Frequency = health.CheckOnce
 	}
 
-	m.checkers.Set(name, wrapper{
+	w := wrapper{
 		opts:    o,
 		checker: checker,
+	}
+
+	m.stateMx.Lock()
+	defer m.stateMx.Unlock()
+
+	if !m.running() {
+		m.checkers.Set(name, w)
+		return nil
+	}
+
+	candidates := m.checkers.Value()
+	candidates[name] = w
+	if err := validateDependencies(candidates); err != nil {
+		return err
+	}
+
+	if old, ok := m.checkers.Get(name); ok && old.cancel != nil {
+		old.cancel()
+	}
+	m.checkResults.Delete(name)
+	m.checkers.Set(name, m.dispatchCheck(m.runCtx, name, w))
+	m.updateAllChecksRan()
+
+	return nil
+}
+
+// RemoveCheck removes a named health check from the manager. If the manager
+// is running, the check is stopped, reporters implementing
+// [health.CheckRemover] are told it is gone, and liveness, readiness and
+// startup are re-evaluated without it.
+func (m *Manager) RemoveCheck(name string) error {
+	m.stateMx.Lock()
+	defer m.stateMx.Unlock()
+
+	w, ok := m.checkers.Get(name)
+	if !ok {
+		return fmt.Errorf("%w.manager.std: no health check named '%s'", health.ErrHealth, name)
+	}
+	group, policy, grouped := m.groupPolicy(name)
+	if w.cancel != nil {
+		w.cancel()
+	}
+	m.checkers.Delete(name)
+	m.checkResults.Delete(name)
+	m.clearOverride(&m.checkOverrides, name, nil)
+	m.notifyResult()
+
+	if !m.running() {
+		return nil
+	}
+
+	ctx := m.runCtx
+	m.reporters.Each(func(_ string, reporter health.Reporter) bool {
+		if remover, ok := reporter.(health.CheckRemover); ok {
+			remover.RemoveHealthChecks(ctx, []string{name})
+		}
+		return true
 	})
+	if grouped {
+		m.relayGroup(ctx, group, policy)
+	}
+
+	m.updateAllChecksRan()
+	m.evaluateFitness(ctx)
 
 	return nil
 }
 
 func (m *Manager) Run(ctx context.Context) <-chan error {
+	m.stateMx.Lock()
+	defer m.stateMx.Unlock()
+
 	// if we're already running, get out
 	if !atomic.CompareAndSwapUint32(&m.runningPtr, 0, 1) {
 		return m.errChan
 	}
+	parent := ctx
+	ctx, m.runCancel = context.WithCancel(parent)
+	m.runCtx = ctx
 
 	// if we get an error while starting up, set running back to false
 	var shouldReset bool
 	defer func(reset *bool) {
 		if *reset {
+			m.runCancel()
 			atomic.StoreUint32(&m.runningPtr, 0)
 		}
 	}(&shouldReset)
 
 	m.initInternals()
+	m.resetState()
 
 	// validate and start subsystems; on failure, reset and return
 	if err := m.validateAndStart(ctx); err != nil {
@@ -100,25 +284,38 @@ func (m *Manager) Run(ctx context.Context) <-chan error {
 	}
 
 	// this dispatches a goroutine to poll for two signals: a context
-	// cancellation (meaning the application is closing) and a health checker
-	// response. This goroutine halts when the application is closing.
-	go func(h *Manager) {
+	// cancellation (meaning the application is closing or the manager was
+	// stopped) and a health checker response. This goroutine halts when the
+	// run context is cancelled.
+	m.loopDone = make(chan struct{})
+	go func(h *Manager, done chan struct{}) {
+		defer close(done)
 		for {
 			select {
 			case <-ctx.Done():
-				_ = h.Stop(ctx)
+				if parent.Err() != nil {
+					sctx, cancel := h.shutdownContext(parent)
+					_ = h.stop(sctx, false)
+					cancel()
+				}
 				return
-			case hc := <-h.checkFunnel:
-				h.processHealthCheck(ctx, hc)
+			case msg := <-h.checkFunnel:
+				h.stateMx.Lock()
+				h.processHealthCheck(ctx, msg.hc, msg.gen)
 				h.evaluateFitness(ctx)
+				h.stateMx.Unlock()
 			}
 		}
-	}(m)
+	}(m, m.loopDone)
 
 	m.initStartupState(ctx)
+	m.watchStartupDeadline(ctx)
 
 	// set initial liveness
 	_ = m.setLive(ctx, true)
+
+	// probe overrides set before Run apply before any check reports
+	m.applyPendingProbeOverrides(ctx)
 	return m.errChan
 }
 
@@ -129,8 +326,10 @@ func (m *Manager) initInternals() {
 	}
 	if m.checkFunnel == nil {
 		// buffered channel to prevent checker goroutines from blocking
-		m.checkFunnel = make(chan *health.CheckResult, m.checkers.Size())
+		m.checkFunnel = make(chan checkMessage, m.checkers.Size())
 	}
+	// checks that outlived a timed-out Stop may have sent more since
+	m.discardResults()
 	if m.errChan == nil {
 		// we use a buffered channel here, so we can push a
 		// startup error straight away if we have to
@@ -148,9 +347,16 @@ func (m *Manager) validateAndStart(ctx context.Context) error {
 	if m.reporters.Size() == 0 {
 		return fmt.Errorf("%w.manager.std: there are no reporters specified for this manager", health.ErrHealth)
 	}
+	if err := validateDependencies(m.checkers.Value()); err != nil {
+		return err
+	}
 	if err := m.dispatchReporters(ctx); err != nil {
 		return err
 	}
+	m.reporters.Each(func(_ string, reporter health.Reporter) bool {
+		m.seedProbeOverrides(ctx, reporter)
+		return true
+	})
 	return m.dispatchHealthChecks(ctx)
 }
 
@@ -167,18 +373,59 @@ func (m *Manager) initStartupState(ctx context.Context) {
 	})
 
 	if !hasStartupChecks {
-		atomic.StoreUint32(&m.startupDone, 1)
-		m.setStartup(ctx, true)
+		m.markStartupDone(ctx)
 	}
 }
 
+// Stop the manager. Readiness is set false first and, if DrainPeriod or
+// DrainProbes is set, reporters keep serving until the drain is over. Then
+// pre-stop hooks run, reporters are stopped and checks are cancelled, and
+// Stop waits for every check goroutine to exit. The whole sequence is bounded
+// by ctx or, if ctx is already done, by ShutdownTimeout. If the manager is
+// already stopping because its Run context was cancelled, Stop waits for that
+// to finish. A stopped manager can be run again.
 func (m *Manager) Stop(ctx context.Context) error {
+	if ctx.Err() != nil {
+		var cancel context.CancelFunc
+		ctx, cancel = m.shutdownContext(ctx)
+		defer cancel()
+	}
+	return m.stop(ctx, true)
+}
+
+// shutdownContext returns a context for a stop sequence that is not
+// cancelled with parent, bounded by ShutdownTimeout.
+func (m *Manager) shutdownContext(parent context.Context) (context.Context, context.CancelFunc) {
+	timeout := m.ShutdownTimeout
+	if timeout <= 0 {
+		timeout = m.DrainPeriod + DefaultShutdownTimeout
+	}
+	return context.WithTimeout(context.WithoutCancel(parent), timeout)
+}
+
+// stop runs the stop sequence. The result loop calls it with waitLoop false
+// when the Run context is cancelled, since it can't wait for itself.
+func (m *Manager) stop(ctx context.Context, waitLoop bool) error {
+	// the result loop evaluates readiness under stateMx, so holding it here
+	// keeps an evaluation already past its running check from setting
+	// readiness true again once it has been set false for the drain
+	m.stateMx.Lock()
 	if !atomic.CompareAndSwapUint32(&m.runningPtr, 1, 0) {
+		m.stateMx.Unlock()
+		if waitLoop {
+			m.awaitLoop(ctx)
+		}
 		return nil
 	}
-
 	_ = m.setReady(ctx, false)
+	m.stateMx.Unlock()
+
+	m.drain(ctx)
+
 	var errs []error
+	if err := m.runPreStopHooks(ctx); err != nil {
+		errs = append(errs, err)
+	}
 
 	m.reporters.Each(func(key string, reporter health.Reporter) bool {
 		rErr := reporter.Stop(ctx)
@@ -188,6 +435,10 @@ func (m *Manager) Stop(ctx context.Context) error {
 		return true
 	})
 
+	if err := m.stopChecks(ctx, waitLoop); err != nil {
+		errs = append(errs, err)
+	}
+
 	if len(errs) > 0 {
 		return fmt.Errorf("%w.manager.std: %w", health.ErrHealth, errors.Join(errs...))
 	}
@@ -195,15 +446,137 @@ func (m *Manager) Stop(ctx context.Context) error {
 	return nil
 }
 
+// AddReporter adds a named reporter to the manager. If the manager is already
+// running, the reporter is started and brought up to date with the current
+// liveness, readiness, startup and check results. A running reporter with the
+// same name is stopped and replaced.
 func (m *Manager) AddReporter(name string, r health.Reporter) error {
-	if m.running() {
-		return fmt.Errorf("%w.manager.std: cannot add a reporter to a running health instance", health.ErrHealth)
+	m.stateMx.Lock()
+	defer m.stateMx.Unlock()
+
+	if !m.running() {
+		m.reporters.Set(name, r)
+		return nil
 	}
 
+	ctx := m.runCtx
+	if err := r.Run(ctx); err != nil {
+		return fmt.Errorf("%w.manager.std: reporter '%s' failed to start: %w", health.ErrHealth, name, err)
+	}
+	if old, ok := m.reporters.Get(name); ok {
+		if err := old.Stop(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			m.Logger.Error("replaced reporter failed to stop", "reporter", name, "error", err)
+		}
+	}
+
+	m.seedReporter(ctx, r)
+	m.seedProbeOverrides(ctx, r)
 	m.reporters.Set(name, r)
 	return nil
 }
 
+// stopChecks cancels the run context and waits, bounded by ctx, for every
+// check goroutine and, if waitLoop is set, the result loop to exit.
+func (m *Manager) stopChecks(ctx context.Context, waitLoop bool) error {
+	m.stateMx.Lock()
+	m.runCancel()
+	loopDone := m.loopDone
+	m.stateMx.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		m.checksWG.Wait()
+		if waitLoop {
+			<-loopDone
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		m.discardResults()
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("checks did not stop: %w", ctx.Err())
+	}
+}
+
+// awaitLoop waits for the result loop of the last run to exit, which it does
+// after any stop sequence it started.
+func (m *Manager) awaitLoop(ctx context.Context) {
+	m.stateMx.Lock()
+	loopDone := m.loopDone
+	m.stateMx.Unlock()
+	if loopDone == nil {
+		return
+	}
+	select {
+	case <-loopDone:
+	case <-ctx.Done():
+	}
+}
+
+// discardResults empties the result funnel, so that results sent before the
+// manager stopped are not processed by the next run.
+func (m *Manager) discardResults() {
+	for {
+		select {
+		case <-m.checkFunnel:
+		default:
+			return
+		}
+	}
+}
+
+// resetState clears the results and probe state left by a previous run.
+func (m *Manager) resetState() {
+	m.checkResults.Clear()
+	atomic.StoreUint32(&m.livePtr, 0)
+	atomic.StoreUint32(&m.readyPtr, 0)
+	atomic.StoreUint32(&m.startupPtr, 0)
+	atomic.StoreUint32(&m.allChecksRan, 0)
+	atomic.StoreUint32(&m.initialReady, 0)
+	atomic.StoreUint32(&m.startupDone, 0)
+
+	m.pool = nil
+	if m.MaxConcurrentChecks > 0 {
+		m.pool = newWorkerPool(m.MaxConcurrentChecks)
+	}
+}
+
+// send delivers a check result from the given dispatch of a check to the
+// result loop. Returns false if the context is done first.
+func (m *Manager) send(ctx context.Context, w *wrapper, hc *health.CheckResult) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	select {
+	case m.checkFunnel <- checkMessage{gen: w.gen, hc: hc}:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// seedReporter relays the current probe states and latest check results to a
+// reporter added after the manager started.
+func (m *Manager) seedReporter(ctx context.Context, r health.Reporter) {
+	r.SetLiveness(ctx, m.isLive())
+	r.SetReadiness(ctx, m.isReady())
+	r.SetStartup(ctx, m.isStartup())
+
+	hcs := make(map[string]*health.CheckResult)
+	m.checkResults.Each(func(name string, value result) bool {
+		if value.last != nil {
+			hcs[name] = value.last
+		}
+		return true
+	})
+	if len(hcs) > 0 {
+		r.UpdateHealthChecks(ctx, hcs)
+	}
+}
+
 // setLive sets liveness and notifies reporters if it changed.
 func (m *Manager) setLive(ctx context.Context, b bool) bool {
 	var v uint32
@@ -220,6 +593,7 @@ func (m *Manager) setLive(ctx context.Context, b bool) bool {
 			value.SetLiveness(ctx, live)
 			return true
 		})
+		m.emit(Event{Kind: EventLiveness, Value: live})
 	}
 
 	return changed
@@ -235,7 +609,12 @@ func (m *Manager) setReady(ctx context.Context, b bool) bool {
 	if b && atomic.LoadUint32(&m.startupDone) == 0 {
 		return false
 	}
+	return m.storeReady(ctx, b)
+}
 
+// storeReady sets readiness and notifies reporters if it changed, whether or
+// not the checks have reported. Probe overrides use it directly.
+func (m *Manager) storeReady(ctx context.Context, b bool) bool {
 	var v uint32
 	if b {
 		v = 1
@@ -249,6 +628,7 @@ func (m *Manager) setReady(ctx context.Context, b bool) bool {
 			reporter.SetReadiness(ctx, ready)
 			return true
 		})
+		m.emit(Event{Kind: EventReadiness, Value: ready})
 	}
 
 	return changed
@@ -269,13 +649,15 @@ func (m *Manager) setStartup(ctx context.Context, b bool) bool {
 			reporter.SetStartup(ctx, startup)
 			return true
 		})
+		m.emit(Event{Kind: EventStartup, Value: startup})
 	}
 
 	return changed
 }
 
-// process a health check result received from a checker.
-func (m *Manager) processHealthCheck(ctx context.Context, hc *health.CheckResult) {
+// process a health check result received from a checker. gen is the
+// generation of the dispatch that produced it.
+func (m *Manager) processHealthCheck(ctx context.Context, hc *health.CheckResult, gen uint64) {
 	if hc == nil {
 		m.Logger.Error("received nil health check result")
 		return
@@ -287,21 +669,48 @@ func (m *Manager) processHealthCheck(ctx context.Context, hc *health.CheckResult
 	default:
 	}
 
+	// drop results from checks that were removed, replaced or restarted
+	// while the result was in flight
+	w, ok := m.checkers.Get(hc.Name)
+	if !ok || w.gen != gen {
+		return
+	}
+
 	var r result
+	prev, _ := m.checkResults.Get(hc.Name)
+	r.actualFailing = hc.Status == health.StatusUnhealthy || hc.Status == health.StatusSkipped
+	r.history = recordHistory(prev.history, hc, m.historySize())
+	hc = applyThresholds(hc, prev, &r, &w.opts)
+	r.actual = applyHistory(hc, r.history, &w.opts)
+
+	if r.actual.Error != nil && r.actual.Status != health.StatusSkipped {
+		m.Logger.Error("health check returned an error", "check", hc.Name, "error", r.actual.Error)
+	}
+
+	m.publishResult(ctx, prev, r)
+}
+
+// publishResult applies any override to a check's latest real result,
+// records it, and relays it to reporters.
+func (m *Manager) publishResult(ctx context.Context, prev, r result) {
+	hc := r.actual
+	r.failing = r.actualFailing
+	r.cancelLive, r.cancelReady = false, false
+	if o, ok := m.checkOverrides[hc.Name]; ok {
+		hc = o.apply(hc)
+		r.failing = hc.Status == health.StatusUnhealthy || hc.Status == health.StatusSkipped
+	}
+	r.last = hc
 	defer func(m *Manager, hc *health.CheckResult, r *result) {
 		m.checkResults.Set(hc.Name, *r)
 
 		// check if all registered checks have reported in
 		if atomic.LoadUint32(&m.allChecksRan) == 0 {
-			if m.checkResults.Size() == m.checkers.Size() {
-				atomic.StoreUint32(&m.allChecksRan, 1)
-			}
+			m.updateAllChecksRan()
 		}
-	}(m, hc, &r)
 
-	if hc.Error != nil {
-		m.Logger.Error("health check returned an error", "check", hc.Name, "error", hc.Error)
-	}
+		m.notifyResult()
+	}(m, hc, &r)
 
 	switch hc.Status {
 	case health.StatusUnhealthy:
@@ -315,15 +724,23 @@ func (m *Manager) processHealthCheck(ctx context.Context, hc *health.CheckResult
 	case health.StatusDegraded:
 		// degraded checks are reported but do not fail probes
 		m.Logger.Warn("health check degraded", "check", hc.Name)
+	case health.StatusSkipped:
+		// the failing dependency already accounts for the probes
+		m.Logger.Debug("health check skipped", "check", hc.Name, "reason", hc.Error)
+	}
+
+	hcs := map[string]*health.CheckResult{hc.Name: hc}
+	if group, policy, ok := m.groupPolicy(hc.Name); ok {
+		hcs = m.groupResults(group, policy, &r)
 	}
 
 	// relay check result to reporters
 	m.reporters.Each(func(_ string, value health.Reporter) bool {
-		value.UpdateHealthChecks(ctx, map[string]*health.CheckResult{
-			hc.Name: hc,
-		})
+		value.UpdateHealthChecks(ctx, hcs)
 		return true
 	})
+
+	m.emitCheckStatus(prev.last, r.last)
 }
 
 // start up reporters.
@@ -348,20 +765,55 @@ func (m *Manager) dispatchHealthChecks(ctx context.Context) error {
 	default:
 	}
 
-	m.checkers.Each(func(key string, w wrapper) bool {
-		if w.opts.Frequency&health.CheckAtInterval == health.CheckAtInterval {
-			go m.dispatchIntervalCheck(ctx, key, &w)
-			return true
+	for name, w := range m.checkers.Value() {
+		m.checkers.Set(name, m.dispatchCheck(ctx, name, w))
+	}
+
+	return nil
+}
+
+// dispatchCheck starts the goroutine for a single health check. The returned
+// wrapper carries the cancel func that stops it.
+func (m *Manager) dispatchCheck(ctx context.Context, name string, w wrapper) wrapper {
+	ctx, w.cancel = context.WithCancel(ctx)
+	w.inflight = new(atomic.Int64)
+	w.gen = atomic.AddUint64(&m.checkGen, 1)
+	m.checksWG.Add(1)
+	go func(w wrapper) {
+		defer m.checksWG.Done()
+		p, passive := w.checker.(*PassiveCheck)
+		switch {
+		case passive:
+			m.dispatchPassiveCheck(ctx, name, &w, p)
+		case w.opts.Frequency&health.CheckAtInterval == health.CheckAtInterval:
+			m.dispatchIntervalCheck(ctx, name, &w)
+		default:
+			m.dispatchOneTimeCheck(ctx, name, &w)
+		}
+	}(w)
+	return w
+}
+
+// updateAllChecksRan records whether every registered check has reported at
+// least once.
+func (m *Manager) updateAllChecksRan() {
+	var ran uint32 = 1
+	m.checkers.Each(func(name string, _ wrapper) bool {
+		if _, ok := m.checkResults.Get(name); !ok {
+			ran = 0
+			return false
 		}
-		go m.dispatchOneTimeCheck(ctx, key, &w)
 		return true
 	})
-
-	return nil
+	atomic.StoreUint32(&m.allChecksRan, ran)
 }
 
-// applyCheckOptions overrides check result fields from the registered options.
-func applyCheckOptions(hc *health.CheckResult, name string, opts *health.AddCheckOptions) {
+// applyCheckOptions returns a copy of a check result with fields overridden
+// from the registered options. Checkers may return the same result more than
+// once, so it is never modified in place.
+func applyCheckOptions(hc *health.CheckResult, name string, opts *health.AddCheckOptions) *health.CheckResult {
+	out := *hc
+	hc = &out
 	hc.Name = name
 	hc.AffectsLiveness = opts.AffectsLiveness
 	hc.AffectsReadiness = opts.AffectsReadiness
@@ -369,29 +821,54 @@ func applyCheckOptions(hc *health.CheckResult, name string, opts *health.AddChec
 	hc.Group = opts.Group
 	hc.ComponentType = opts.ComponentType
 	hc.DependsOn = opts.DependsOn
+	return hc
 }
 
 // dispatchIntervalCheck dispatches health checks at a regular interval.
 func (m *Manager) dispatchIntervalCheck(ctx context.Context, name string, w *wrapper) {
 	if w.opts.Frequency&health.CheckAfter == health.CheckAfter {
 		m.Logger.Debug("delaying checker", "checker", name, "delay", w.opts.Delay)
-		time.Sleep(w.opts.Delay)
+		if !sleep(ctx, w.opts.Delay) {
+			return
+		}
 	}
 
-	t := time.NewTicker(w.opts.Interval)
-	m.Logger.Debug("running interval checker", "checker", name, "interval", w.opts.Interval)
+	if !m.awaitDependencies(ctx, name, w) {
+		return
+	}
+
+	var failures int
+	t := time.NewTimer(nextInterval(&w.opts, failures))
+	m.Logger.Debug("running interval checker", "checker", name, "interval", checkInterval(&w.opts))
 	for {
 		select {
 		case <-ctx.Done():
 			t.Stop()
 			return
 		case <-t.C:
-			hc := m.safeCheck(ctx, name, w)
-			if hc == nil {
+			if dep := m.failingDependency(w); dep != "" {
+				hc := skippedResult(name, dep)
+				hc = applyCheckOptions(hc, name, &w.opts)
+				if !m.send(ctx, w, hc) {
+					return
+				}
+				t.Reset(nextInterval(&w.opts, failures))
 				continue
 			}
-			applyCheckOptions(hc, name, &w.opts)
-			m.checkFunnel <- hc
+
+			hc := m.safeCheck(ctx, name, w)
+			if hc != nil {
+				if hc.Status == health.StatusUnhealthy {
+					failures++
+				} else {
+					failures = 0
+				}
+				hc = applyCheckOptions(hc, name, &w.opts)
+				if !m.send(ctx, w, hc) {
+					return
+				}
+			}
+			t.Reset(nextInterval(&w.opts, failures))
 		}
 	}
 }
@@ -400,7 +877,13 @@ func (m *Manager) dispatchIntervalCheck(ctx context.Context, name string, w *wra
 func (m *Manager) dispatchOneTimeCheck(ctx context.Context, name string, w *wrapper) {
 	if w.opts.Frequency&health.CheckAfter == health.CheckAfter {
 		m.Logger.Debug("delaying checker", "checker", name, "delay", w.opts.Delay)
-		time.Sleep(w.opts.Delay)
+		if !sleep(ctx, w.opts.Delay) {
+			return
+		}
+	}
+
+	if !m.awaitOneTimeDependencies(ctx, name, w) {
+		return
 	}
 
 	m.Logger.Debug("running one-time checker", "checker", name)
@@ -412,9 +895,113 @@ func (m *Manager) dispatchOneTimeCheck(ctx context.Context, name string, w *wrap
 		if hc == nil {
 			return
 		}
-		applyCheckOptions(hc, name, &w.opts)
-		m.checkFunnel <- hc
+		hc = applyCheckOptions(hc, name, &w.opts)
+		m.send(ctx, w, hc)
+	}
+}
+
+// awaitOneTimeDependencies blocks a one-time check until its local
+// dependencies have all reported and none is failing, reporting the check as
+// skipped once for each dependency it waits on to recover. Returns false if
+// the context is done first.
+func (m *Manager) awaitOneTimeDependencies(ctx context.Context, name string, w *wrapper) bool {
+	if !m.awaitDependencies(ctx, name, w) {
+		return false
+	}
+
+	var skippedFor string
+	for {
+		notify := m.resultNotify()
+		dep := m.failingDependency(w)
+		if dep == "" {
+			return true
+		}
+		if dep != skippedFor {
+			skippedFor = dep
+			hc := skippedResult(name, dep)
+			hc = applyCheckOptions(hc, name, &w.opts)
+			if !m.send(ctx, w, hc) {
+				return false
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return false
+		case <-notify:
+		}
+	}
+}
+
+// applyThresholds tracks consecutive failures and successes for a check and
+// returns the result as it should be evaluated and reported. A check must fail
+// FailureThreshold times in a row before it is unhealthy, and must then pass
+// SuccessThreshold times in a row before it is healthy again. In between, the
+// result is reported as degraded while failing, or unhealthy while recovering,
+// with the running count in its Metadata. Like a Kubernetes readiness probe, a
+// check starts out failed: until it first reaches SuccessThreshold, failures
+// are not tolerated.
+func applyThresholds(hc *health.CheckResult, prev result, next *result, opts *health.AddCheckOptions) *health.CheckResult {
+	failureThreshold := max(opts.FailureThreshold, 1)
+	successThreshold := max(opts.SuccessThreshold, 1)
+
+	// a check without a previous result has never passed
+	next.tripped = prev.tripped || prev.actual == nil
+	if hc.Status == health.StatusSkipped {
+		// a skipped check neither passed nor failed
+		next.failures, next.successes = prev.failures, prev.successes
+		return hc
+	}
+
+	if hc.Status == health.StatusUnhealthy {
+		next.failures = prev.failures + 1
+		if next.failures >= failureThreshold {
+			next.tripped = true
+		}
+	} else {
+		next.successes = prev.successes + 1
+		if next.successes >= successThreshold {
+			next.tripped = false
+		}
 	}
+
+	switch {
+	case hc.Status == health.StatusUnhealthy && !next.tripped:
+		out := withMetadata(hc,
+			"consecutiveFailures", strconv.Itoa(next.failures),
+			"failureThreshold", strconv.Itoa(failureThreshold),
+		)
+		out.Status = health.StatusDegraded
+		return out
+	case hc.Status != health.StatusUnhealthy && next.tripped:
+		out := withMetadata(hc,
+			"consecutiveSuccesses", strconv.Itoa(next.successes),
+			"successThreshold", strconv.Itoa(successThreshold),
+		)
+		out.Status = health.StatusUnhealthy
+		out.Error = fmt.Errorf("recovering: %d of %d consecutive successes", next.successes, successThreshold)
+		out.ErrorSince = hc.Timestamp
+		if prev.actual != nil && !prev.actual.ErrorSince.IsZero() {
+			out.ErrorSince = prev.actual.ErrorSince
+		}
+		return out
+	}
+
+	return hc
+}
+
+// withMetadata returns a copy of hc with the given key-value pairs added to
+// its Metadata. The checker's own result and map are left untouched, since
+// they may be shared (e.g. by a CachedChecker).
+func withMetadata(hc *health.CheckResult, kv ...string) *health.CheckResult {
+	out := *hc
+	out.Metadata = make(map[string]string, len(hc.Metadata)+len(kv)/2)
+	for k, v := range hc.Metadata {
+		out.Metadata[k] = v
+	}
+	for i := 0; i+1 < len(kv); i += 2 {
+		out.Metadata[kv[i]] = kv[i+1]
+	}
+	return &out
 }
 
 // evaluateFitness evaluates all check results and updates liveness, readiness,
@@ -425,17 +1012,21 @@ func (m *Manager) evaluateFitness(ctx context.Context) {
 		return
 	}
 
-	// only do this if all checks have been performed at least once
+	// only do this if all checks have been performed at least once; probe
+	// overrides still apply in the meantime
 	if atomic.LoadUint32(&m.allChecksRan) == 0 {
+		m.applyPendingProbeOverrides(ctx)
 		return
 	}
 
 	// evaluate startup checks first; bail if startup is still pending
 	if !m.evaluateStartup(ctx) {
+		m.applyPendingProbeOverrides(ctx)
 		return
 	}
 
 	actuallyLive, actuallyReady := m.aggregateResults()
+	actuallyLive, actuallyReady = m.applyProbeOverrides(actuallyLive, actuallyReady)
 
 	reportedReadiness := m.isReady()
 
@@ -452,6 +1043,11 @@ func (m *Manager) evaluateFitness(ctx context.Context) {
 	// cant be ready if you aren't live
 	actuallyReady = actuallyReady && actuallyLive
 
+	if !actuallyLive && m.inLivenessGrace() {
+		m.Logger.Warn("liveness failure tolerated during grace period", "grace", m.LivenessGracePeriod)
+		actuallyLive = true
+	}
+
 	m.applyLivenessChange(ctx, actuallyLive)
 	m.applyReadinessChange(ctx, reportedReadiness, actuallyReady)
 }
@@ -470,7 +1066,7 @@ func (m *Manager) evaluateStartup(ctx context.Context) bool {
 			return true
 		}
 		r, ok := m.checkResults.Get(name)
-		if !ok || r.cancelLive || r.cancelReady {
+		if !ok || r.failing || r.cancelLive || r.cancelReady {
 			startupPassed = false
 			return false
 		}
@@ -481,8 +1077,7 @@ func (m *Manager) evaluateStartup(ctx context.Context) bool {
 		return false
 	}
 
-	atomic.StoreUint32(&m.startupDone, 1)
-	m.setStartup(ctx, true)
+	m.markStartupDone(ctx)
 	m.Logger.Info("startup complete")
 	return true
 }
@@ -491,11 +1086,28 @@ func (m *Manager) evaluateStartup(ctx context.Context) bool {
 // liveness and readiness signals.
 func (m *Manager) aggregateResults() (live, ready bool) {
 	live, ready = true, true
-	m.checkResults.Each(func(_ string, value result) bool {
+	groups := make(map[string]*groupTally)
+	m.checkResults.Each(func(name string, value result) bool {
+		if group, policy, ok := m.groupPolicy(name); ok {
+			t, ok := groups[group]
+			if !ok {
+				t = &groupTally{policy: policy}
+				groups[group] = t
+			}
+			t.add(value)
+			return true
+		}
 		live = live && !value.cancelLive
 		ready = ready && !value.cancelReady
 		return true
 	})
+	for _, t := range groups {
+		if t.passed() {
+			continue
+		}
+		live = live && !t.affectsLive
+		ready = ready && !t.affectsReady
+	}
 	return live, ready
 }
 
@@ -525,9 +1137,82 @@ func (m *Manager) applyReadinessChange(ctx context.Context, reportedReadiness, a
 	_ = m.setReady(ctx, actuallyReady)
 }
 
-// safeCheck runs a checker with panic recovery. Returns nil if the checker
-// panics or returns nil.
-func (m *Manager) safeCheck(ctx context.Context, name string, w *wrapper) (result *health.CheckResult) {
+// safeCheck runs a checker, enforcing the check timeout and concurrency limit
+// if they are set.
+func (m *Manager) safeCheck(ctx context.Context, name string, w *wrapper) *health.CheckResult {
+	if m.pool != nil {
+		return m.pooledCheck(ctx, name, w, func() *health.CheckResult {
+			return m.timedCheck(ctx, name, w)
+		})
+	}
+	return m.timedCheck(ctx, name, w)
+}
+
+// timedCheck runs a checker, enforcing the check timeout if one is set.
+func (m *Manager) timedCheck(ctx context.Context, name string, w *wrapper) *health.CheckResult {
+	timeout := w.opts.Timeout
+	if timeout <= 0 {
+		timeout = m.CheckTimeout
+	}
+	if timeout <= 0 {
+		return m.runCheck(ctx, name, w)
+	}
+	return m.runCheckWithTimeout(ctx, name, w, timeout)
+}
+
+// runCheckWithTimeout runs a checker in its own goroutine and abandons it if
+// it overruns the timeout, returning an unhealthy result instead. While an
+// abandoned execution is still running, further executions are not started;
+// they report the overrun instead, so goroutines don't pile up on a checker
+// that ignores its context.
+func (m *Manager) runCheckWithTimeout(ctx context.Context, name string, w *wrapper, timeout time.Duration) *health.CheckResult {
+	start := time.Now()
+	if !w.inflight.CompareAndSwap(0, start.UnixNano()) {
+		since := time.Unix(0, w.inflight.Load())
+		m.Logger.Error("checker still running from a previous execution", "checker", name, "since", since)
+		return &health.CheckResult{
+			Name:       name,
+			Status:     health.StatusUnhealthy,
+			Error:      fmt.Errorf("check timed out after %s: %w", timeout, context.DeadlineExceeded),
+			ErrorSince: since,
+			Duration:   time.Since(since),
+			Timestamp:  start,
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	done := make(chan *health.CheckResult, 1)
+	go func() {
+		defer w.inflight.Store(0)
+		defer cancel()
+		done <- m.runCheck(ctx, name, w)
+	}()
+
+	select {
+	case hc := <-done:
+		return hc
+	case <-ctx.Done():
+		// the goroutine cancels the context once it has delivered its result
+		select {
+		case hc := <-done:
+			return hc
+		default:
+		}
+		m.Logger.Error("checker timed out", "checker", name, "timeout", timeout)
+		return &health.CheckResult{
+			Name:       name,
+			Status:     health.StatusUnhealthy,
+			Error:      fmt.Errorf("check timed out after %s: %w", timeout, ctx.Err()),
+			ErrorSince: start,
+			Duration:   time.Since(start),
+			Timestamp:  start,
+		}
+	}
+}
+
+// runCheck runs a checker with panic recovery. Returns nil if the checker
+// returns nil.
+func (m *Manager) runCheck(ctx context.Context, name string, w *wrapper) (result *health.CheckResult) {
 	defer func() {
 		if r := recover(); r != nil {
 			m.Logger.Error("checker panicked", "checker", name, "panic", r)
@@ -3,6 +3,7 @@ package std_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 	"sync/atomic"
 	"testing"
@@ -117,6 +118,29 @@ func TestManager_IntervalCheck(t *testing.T) {
 	_ = mgr.Stop(ctx)
 }
 
+func TestManager_IntervalCheckZeroInterval(t *testing.T) {
+	mgr := &std.Manager{}
+	var calls atomic.Int32
+
+	_ = mgr.AddCheck("test", health.CheckerFunc(func(_ context.Context) *health.CheckResult {
+		calls.Add(1)
+		return &health.CheckResult{Name: "test", Status: health.StatusUnhealthy}
+	}), health.WithCheckFrequency(health.CheckAtInterval, 0, 0), health.WithBackoff(0, 0, 0))
+	_ = mgr.AddReporter("test", &test.Reporter{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	_ = mgr.Run(ctx)
+
+	// the default interval applies, rather than checking in a busy loop
+	time.Sleep(100 * time.Millisecond)
+	if n := calls.Load(); n > 1 {
+		t.Fatalf("expected a zero interval to use the default, got %d checks in 100ms", n)
+	}
+
+	cancel()
+	_ = mgr.Stop(ctx)
+}
+
 func TestManager_IntervalCheckWithDelay(t *testing.T) {
 	mgr := &std.Manager{}
 	rpt := &test.Reporter{}
@@ -343,10 +367,81 @@ func TestManager_AddCheckWhileRunning(t *testing.T) {
 	err := mgr.AddCheck("test2", health.CheckerFunc(func(_ context.Context) *health.CheckResult {
 		return &health.CheckResult{Name: "test2", Status: health.StatusHealthy}
 	}))
-	if err == nil || !strings.Contains(err.Error(), "cannot add a health check to a running") {
-		t.Fatalf("expected error adding check while running, got: %v", err)
+	if err != nil {
+		t.Fatalf("expected no error adding check while running, got: %v", err)
 	}
 
+	// the new check is dispatched immediately
+	waitFor(t, 2*time.Second, func() bool {
+		return rpt.Report().HealthChecks["test2"] != nil
+	})
+
+	cancel()
+	_ = mgr.Stop(ctx)
+}
+
+func TestManager_ReplacedCheckResultDropped(t *testing.T) {
+	mgr := &std.Manager{}
+	rpt := &MockReporter{}
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	// ignores its context, like a checker whose pool is closed under it
+	_ = mgr.AddCheck("db", health.CheckerFunc(func(_ context.Context) *health.CheckResult {
+		close(started)
+		<-release
+		return &health.CheckResult{Status: health.StatusUnhealthy, Error: errors.New("database is closed")}
+	}), health.WithReadinessImpact())
+	_ = mgr.AddReporter("test", rpt)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_ = mgr.Run(ctx)
+	<-started
+
+	_ = mgr.AddCheck("db", NewMockChecker(&health.CheckResult{Status: health.StatusHealthy}), health.WithReadinessImpact())
+	waitFor(t, 2*time.Second, func() bool {
+		return rpt.IsReady()
+	})
+	close(release)
+	time.Sleep(50 * time.Millisecond)
+
+	for _, hc := range rpt.History() {
+		if hc.Status != health.StatusHealthy {
+			t.Fatalf("expected the replaced check's result to be dropped, got %+v", hc)
+		}
+	}
+	if !rpt.IsReady() {
+		t.Fatal("expected readiness to be unaffected by the replaced check")
+	}
+	_ = mgr.Stop(ctx)
+}
+
+func TestManager_AddCheckWhileRunningGatesReadiness(t *testing.T) {
+	mgr := &std.Manager{}
+	rpt := &test.Reporter{}
+
+	_ = mgr.AddCheck("test", health.CheckerFunc(func(_ context.Context) *health.CheckResult {
+		return &health.CheckResult{Name: "test", Status: health.StatusHealthy}
+	}), health.WithReadinessImpact())
+	_ = mgr.AddReporter("test", rpt)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	_ = mgr.Run(ctx)
+
+	waitFor(t, 2*time.Second, func() bool {
+		return rpt.Report().IsReady
+	})
+
+	// a failing readiness check added at runtime should flip readiness off
+	_ = mgr.AddCheck("broken", health.CheckerFunc(func(_ context.Context) *health.CheckResult {
+		return &health.CheckResult{Name: "broken", Status: health.StatusUnhealthy}
+	}), health.WithReadinessImpact())
+
+	waitFor(t, 2*time.Second, func() bool {
+		return !rpt.Report().IsReady
+	})
+
 	cancel()
 	_ = mgr.Stop(ctx)
 }
@@ -363,15 +458,84 @@ func TestManager_AddReporterWhileRunning(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	_ = mgr.Run(ctx)
 
-	err := mgr.AddReporter("test2", &test.Reporter{})
-	if err == nil || !strings.Contains(err.Error(), "cannot add a reporter") {
-		t.Fatalf("expected error adding reporter while running, got: %v", err)
+	waitFor(t, 2*time.Second, func() bool {
+		return rpt.Report().NumHealthCheckUpdates >= 1
+	})
+
+	rpt2 := &test.Reporter{}
+	if err := mgr.AddReporter("test2", rpt2); err != nil {
+		t.Fatalf("expected no error adding reporter while running, got: %v", err)
+	}
+
+	// the late reporter is started and seeded with current state
+	report := rpt2.Report()
+	if !report.IsRunning {
+		t.Fatal("expected late reporter to be running")
+	}
+	if !report.IsLive {
+		t.Fatal("expected late reporter to be seeded with liveness")
+	}
+	if report.HealthChecks["test"] == nil {
+		t.Fatal("expected late reporter to be seeded with check results")
 	}
 
 	cancel()
 	_ = mgr.Stop(ctx)
 }
 
+func TestManager_RemoveCheck(t *testing.T) {
+	mgr := &std.Manager{}
+	rpt := &test.Reporter{}
+
+	_ = mgr.AddCheck("good", health.CheckerFunc(func(_ context.Context) *health.CheckResult {
+		return &health.CheckResult{Name: "good", Status: health.StatusHealthy}
+	}), health.WithReadinessImpact())
+	_ = mgr.AddCheck("bad", health.CheckerFunc(func(_ context.Context) *health.CheckResult {
+		return &health.CheckResult{Name: "bad", Status: health.StatusUnhealthy}
+	}), health.WithCheckFrequency(health.CheckAtInterval, 50*time.Millisecond, 0), health.WithReadinessImpact())
+	_ = mgr.AddReporter("test", rpt)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	_ = mgr.Run(ctx)
+
+	waitFor(t, 2*time.Second, func() bool {
+		r := rpt.Report()
+		return r.HealthChecks["good"] != nil && r.HealthChecks["bad"] != nil
+	})
+	if rpt.Report().IsReady {
+		t.Fatal("expected not ready while 'bad' is registered")
+	}
+
+	if err := mgr.RemoveCheck("bad"); err != nil {
+		t.Fatalf("unexpected error removing check: %v", err)
+	}
+
+	waitFor(t, 2*time.Second, func() bool {
+		return rpt.Report().IsReady
+	})
+
+	// give any in-flight result a chance to arrive; it must be dropped
+	time.Sleep(100 * time.Millisecond)
+	report := rpt.Report()
+	if _, ok := report.HealthChecks["bad"]; ok {
+		t.Fatal("expected removed check to be dropped from reporter")
+	}
+	if report.NumHealthCheckRemovals != 1 {
+		t.Fatalf("expected 1 health check removal, got %d", report.NumHealthCheckRemovals)
+	}
+
+	cancel()
+	_ = mgr.Stop(ctx)
+}
+
+func TestManager_RemoveUnknownCheck(t *testing.T) {
+	mgr := &std.Manager{}
+	err := mgr.RemoveCheck("nope")
+	if err == nil || !errors.Is(err, health.ErrHealth) {
+		t.Fatalf("expected health error removing unknown check, got: %v", err)
+	}
+}
+
 func TestManager_PanickingChecker(t *testing.T) {
 	mgr := &std.Manager{}
 	rpt := &test.Reporter{}
@@ -479,6 +643,250 @@ func TestManager_CheckWithErrorField(t *testing.T) {
 	_ = mgr.Stop(ctx)
 }
 
+func TestManager_FailureThreshold(t *testing.T) {
+	mgr := &std.Manager{}
+	rpt := &MockReporter{}
+
+	checker := NewMockChecker(
+		&health.CheckResult{Name: "flaky", Status: health.StatusHealthy},
+		&health.CheckResult{Name: "flaky", Status: health.StatusUnhealthy},
+		&health.CheckResult{Name: "flaky", Status: health.StatusUnhealthy},
+		&health.CheckResult{Name: "flaky", Status: health.StatusUnhealthy},
+	)
+
+	_ = mgr.AddCheck("flaky", checker,
+		health.WithCheckFrequency(health.CheckAtInterval, 20*time.Millisecond, 0),
+		health.WithReadinessImpact(),
+		health.WithFailureThreshold(3),
+	)
+	_ = mgr.AddReporter("test", rpt)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	_ = mgr.Run(ctx)
+
+	waitFor(t, 2*time.Second, func() bool {
+		return len(rpt.History()) >= 4
+	})
+
+	cancel()
+	_ = mgr.Stop(ctx)
+
+	history := rpt.History()
+	want := []health.Status{health.StatusHealthy, health.StatusDegraded, health.StatusDegraded, health.StatusUnhealthy}
+	for i, status := range want {
+		if history[i].Status != status {
+			t.Fatalf("result %d: expected %s, got %s", i, status, history[i].Status)
+		}
+	}
+	if got := history[2].Metadata["consecutiveFailures"]; got != "2" {
+		t.Fatalf("expected consecutiveFailures '2' on the second failure, got %q", got)
+	}
+	if history[3].Metadata != nil {
+		t.Fatalf("expected no threshold metadata once unhealthy, got %v", history[3].Metadata)
+	}
+}
+
+func TestManager_FailureThreshold_NeverPassed(t *testing.T) {
+	mgr := &std.Manager{}
+	rpt := &MockReporter{}
+
+	checker := NewMockChecker(&health.CheckResult{Name: "down", Status: health.StatusUnhealthy})
+	_ = mgr.AddCheck("down", checker,
+		health.WithCheckFrequency(health.CheckAtInterval, 20*time.Millisecond, 0),
+		health.WithReadinessImpact(),
+		health.WithFailureThreshold(3),
+	)
+	_ = mgr.AddReporter("test", rpt)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	_ = mgr.Run(ctx)
+
+	waitFor(t, 2*time.Second, func() bool {
+		return len(rpt.History()) >= 2
+	})
+
+	cancel()
+	_ = mgr.Stop(ctx)
+
+	// failures are only tolerated once the check has passed
+	for i, hc := range rpt.History()[:2] {
+		if hc.Status != health.StatusUnhealthy {
+			t.Fatalf("result %d: expected unhealthy before the first pass, got %s", i, hc.Status)
+		}
+	}
+	if rpt.IsReady() {
+		t.Fatal("expected not ready while a check has never passed")
+	}
+}
+
+func TestManager_SuccessThreshold(t *testing.T) {
+	mgr := &std.Manager{}
+	rpt := &MockReporter{}
+
+	checker := NewMockChecker(
+		&health.CheckResult{Name: "recovering", Status: health.StatusUnhealthy},
+		&health.CheckResult{Name: "recovering", Status: health.StatusHealthy},
+		&health.CheckResult{Name: "recovering", Status: health.StatusHealthy},
+	)
+
+	_ = mgr.AddCheck("recovering", checker,
+		health.WithCheckFrequency(health.CheckAtInterval, 20*time.Millisecond, 0),
+		health.WithReadinessImpact(),
+		health.WithSuccessThreshold(2),
+	)
+	_ = mgr.AddReporter("test", rpt)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	_ = mgr.Run(ctx)
+
+	waitFor(t, 2*time.Second, func() bool {
+		return rpt.IsReady()
+	})
+
+	cancel()
+	_ = mgr.Stop(ctx)
+
+	history := rpt.History()
+	if len(history) < 3 {
+		t.Fatalf("expected at least 3 results, got %d", len(history))
+	}
+	if history[1].Status != health.StatusUnhealthy {
+		t.Fatalf("expected first pass after failure to stay unhealthy, got %s", history[1].Status)
+	}
+	if got := history[1].Metadata["consecutiveSuccesses"]; got != "1" {
+		t.Fatalf("expected consecutiveSuccesses '1', got %q", got)
+	}
+	if history[2].Status != health.StatusHealthy {
+		t.Fatalf("expected healthy after reaching the success threshold, got %s", history[2].Status)
+	}
+}
+
+func TestManager_CheckTimeout(t *testing.T) {
+	mgr := &std.Manager{}
+	rpt := &MockReporter{}
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	defer close(release)
+
+	// ignores its context and blocks until the test ends
+	_ = mgr.AddCheck("hung", health.CheckerFunc(func(_ context.Context) *health.CheckResult {
+		calls.Add(1)
+		<-release
+		return &health.CheckResult{Name: "hung", Status: health.StatusHealthy}
+	}),
+		health.WithCheckFrequency(health.CheckAtInterval, 20*time.Millisecond, 0),
+		health.WithCheckTimeout(50*time.Millisecond),
+		health.WithReadinessImpact(),
+	)
+	_ = mgr.AddReporter("test", rpt)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	_ = mgr.Run(ctx)
+
+	waitFor(t, 2*time.Second, func() bool {
+		return len(rpt.History()) >= 3
+	})
+
+	cancel()
+	_ = mgr.Stop(ctx)
+
+	hc := rpt.History()[0]
+	if hc.Status != health.StatusUnhealthy {
+		t.Fatalf("expected unhealthy on timeout, got %s", hc.Status)
+	}
+	if !errors.Is(hc.Error, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded error, got %v", hc.Error)
+	}
+	if hc.Duration < 50*time.Millisecond {
+		t.Fatalf("expected duration of at least the timeout, got %s", hc.Duration)
+	}
+
+	// the hung execution is never piled on
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("expected 1 checker invocation while the first is hung, got %d", n)
+	}
+}
+
+func TestManager_DefaultCheckTimeout(t *testing.T) {
+	mgr := &std.Manager{CheckTimeout: 50 * time.Millisecond}
+	rpt := &test.Reporter{}
+
+	_ = mgr.AddCheck("slow", health.CheckerFunc(func(ctx context.Context) *health.CheckResult {
+		<-ctx.Done()
+		return &health.CheckResult{Name: "slow", Status: health.StatusUnhealthy, Error: ctx.Err()}
+	}))
+	_ = mgr.AddCheck("fast", health.CheckerFunc(func(_ context.Context) *health.CheckResult {
+		return &health.CheckResult{Name: "fast", Status: health.StatusHealthy}
+	}))
+	_ = mgr.AddReporter("test", rpt)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	_ = mgr.Run(ctx)
+
+	waitFor(t, 2*time.Second, func() bool {
+		return rpt.Report().NumHealthCheckUpdates >= 2
+	})
+
+	report := rpt.Report()
+	if report.HealthChecks["slow"].Status != health.StatusUnhealthy {
+		t.Fatalf("expected slow check to time out, got %s", report.HealthChecks["slow"].Status)
+	}
+	if report.HealthChecks["fast"].Status != health.StatusHealthy {
+		t.Fatalf("expected fast check to pass under the timeout, got %s", report.HealthChecks["fast"].Status)
+	}
+
+	cancel()
+	_ = mgr.Stop(ctx)
+}
+
+func TestManager_BackoffRetriesFaster(t *testing.T) {
+	mgr := &std.Manager{}
+	rpt := &test.Reporter{}
+
+	_ = mgr.AddCheck("failing", health.CheckerFunc(func(_ context.Context) *health.CheckResult {
+		return &health.CheckResult{Name: "failing", Status: health.StatusUnhealthy}
+	}),
+		health.WithCheckFrequency(health.CheckAtInterval, 500*time.Millisecond, 0),
+		health.WithBackoff(10*time.Millisecond, 10*time.Millisecond, 1),
+	)
+	_ = mgr.AddReporter("test", rpt)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	_ = mgr.Run(ctx)
+
+	// without backoff only one execution fits in this window
+	waitFor(t, 900*time.Millisecond, func() bool {
+		return rpt.Report().NumHealthCheckUpdates >= 5
+	})
+
+	cancel()
+	_ = mgr.Stop(ctx)
+}
+
+func TestManager_JitteredIntervalCheck(t *testing.T) {
+	mgr := &std.Manager{}
+	rpt := &test.Reporter{}
+
+	_ = mgr.AddCheck("test", health.CheckerFunc(func(_ context.Context) *health.CheckResult {
+		return &health.CheckResult{Name: "test", Status: health.StatusHealthy}
+	}),
+		health.WithJitter(0.5),
+		health.WithCheckFrequency(health.CheckAtInterval, 20*time.Millisecond, 0),
+	)
+	_ = mgr.AddReporter("test", rpt)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	_ = mgr.Run(ctx)
+
+	waitFor(t, 2*time.Second, func() bool {
+		return rpt.Report().NumHealthCheckUpdates >= 3
+	})
+
+	cancel()
+	_ = mgr.Stop(ctx)
+}
+
 // waitFor polls the condition at 10ms intervals until it returns true or the timeout expires.
 func waitFor(t *testing.T, timeout time.Duration, condition func() bool) {
 	t.Helper()
@@ -491,3 +899,44 @@ func waitFor(t *testing.T, timeout time.Duration, condition func() bool) {
 	}
 	t.Fatalf("condition not met within %s", timeout)
 }
+
+func TestManager_MaxConcurrentChecks(t *testing.T) {
+	mgr := &std.Manager{MaxConcurrentChecks: 2}
+	rpt := &test.Reporter{}
+
+	var running, peak atomic.Int32
+	for i := range 8 {
+		name := fmt.Sprintf("check-%d", i)
+		_ = mgr.AddCheck(name, health.CheckerFunc(func(_ context.Context) *health.CheckResult {
+			n := running.Add(1)
+			defer running.Add(-1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+			return &health.CheckResult{Status: health.StatusHealthy}
+		}))
+	}
+	_ = mgr.AddReporter("test", rpt)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_ = mgr.Run(ctx)
+
+	waitFor(t, 2*time.Second, func() bool {
+		return len(rpt.Report().HealthChecks) == 8
+	})
+	if p := peak.Load(); p > 2 {
+		t.Fatalf("expected at most 2 concurrent checks, saw %d", p)
+	}
+	for name, hc := range rpt.Report().HealthChecks {
+		if _, ok := hc.Metadata["queueWait"]; !ok {
+			t.Fatalf("expected queueWait metadata on %s", name)
+		}
+	}
+
+	_ = mgr.Stop(ctx)
+}
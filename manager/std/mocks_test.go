@@ -46,6 +46,7 @@ type MockReporter struct {
 	updateCount    uint32
 	hcMx           sync.RWMutex
 	hcs            map[string]*health.CheckResult
+	history        []*health.CheckResult
 }
 
 func (m *MockReporter) Run(_ context.Context) error {
@@ -96,6 +97,22 @@ func (m *MockReporter) UpdateHealthChecks(_ context.Context, checks map[string]*
 	}
 	for k, v := range checks {
 		m.hcs[k] = v
+		m.history = append(m.history, v)
 	}
 	atomic.AddUint32(&m.updateCount, 1)
 }
+
+// History returns every check result received, in order.
+func (m *MockReporter) History() []*health.CheckResult {
+	m.hcMx.RLock()
+	defer m.hcMx.RUnlock()
+
+	out := make([]*health.CheckResult, len(m.history))
+	copy(out, m.history)
+	return out
+}
+
+// IsReady reports whether readiness was last set true.
+func (m *MockReporter) IsReady() bool {
+	return atomic.LoadUint32(&m.ready) == 1
+}
@@ -0,0 +1,272 @@
+package std
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/schigh/health/v2"
+)
+
+// Probe identifies a probe that can be overridden with
+// [Manager.OverrideProbe].
+type Probe string
+
+const (
+	// ProbeLiveness is the liveness probe.
+	ProbeLiveness Probe = "liveness"
+
+	// ProbeReadiness is the readiness probe.
+	ProbeReadiness Probe = "readiness"
+)
+
+// override is an operator-set status for a check or probe.
+type override struct {
+	status  health.Status
+	value   bool
+	reason  string
+	expires time.Time
+	timer   *time.Timer
+
+	// prior is a probe's value before it was overridden, restored if the
+	// override is lifted before the probes are evaluated.
+	prior bool
+}
+
+// apply returns a copy of a check result with the override's status and
+// reason.
+func (o *override) apply(hc *health.CheckResult) *health.CheckResult {
+	kv := []string{
+		"override", o.reason,
+		"actualStatus", hc.Status.String(),
+	}
+	if !o.expires.IsZero() {
+		kv = append(kv, "overrideExpires", o.expires.Format(time.RFC3339))
+	}
+	out := withMetadata(hc, kv...)
+	out.Status = o.status
+	if o.status != health.StatusUnhealthy {
+		out.Error = nil
+		out.ErrorSince = time.Time{}
+	}
+	return out
+}
+
+// OverrideCheck forces the reported status of a check, for example to mark
+// a database healthy or skipped during planned maintenance. The check keeps
+// running, and its real result is reported again when the override is
+// cleared or, if ttl is positive, expires. The reason is recorded in the
+// check's Metadata under "override", alongside its real status under
+// "actualStatus".
+func (m *Manager) OverrideCheck(name string, status health.Status, reason string, ttl time.Duration) error {
+	m.stateMx.Lock()
+	defer m.stateMx.Unlock()
+
+	if _, ok := m.checkers.Get(name); !ok {
+		return fmt.Errorf("%w.manager.std: no health check named '%s'", health.ErrHealth, name)
+	}
+
+	o := &override{status: status, reason: reason}
+	m.setOverride(&m.checkOverrides, name, o, ttl)
+	m.republish(name)
+	return nil
+}
+
+// ClearCheckOverride removes an override set with OverrideCheck and reports
+// the check's real result again.
+func (m *Manager) ClearCheckOverride(name string) {
+	m.stateMx.Lock()
+	defer m.stateMx.Unlock()
+
+	if m.clearOverride(&m.checkOverrides, name, nil) {
+		m.republish(name)
+	}
+}
+
+// OverrideProbe forces liveness or readiness to the given value regardless
+// of check results, for example to take the service out of rotation during
+// maintenance. It applies at once, even while checks have yet to report or
+// startup is pending. The override is lifted when cleared or, if ttl is
+// positive, when it expires. Reporters implementing [health.OverrideReporter] are told
+// the reason.
+func (m *Manager) OverrideProbe(probe Probe, value bool, reason string, ttl time.Duration) error {
+	if probe != ProbeLiveness && probe != ProbeReadiness {
+		return fmt.Errorf("%w.manager.std: cannot override probe '%s'", health.ErrHealth, probe)
+	}
+
+	m.stateMx.Lock()
+	defer m.stateMx.Unlock()
+
+	o := &override{value: value, reason: reason, prior: m.probeValue(string(probe))}
+	if current, ok := m.probeOverrides[string(probe)]; ok {
+		o.prior = current.prior
+	}
+	m.setOverride(&m.probeOverrides, string(probe), o, ttl)
+	m.relayProbeOverride(string(probe), reason)
+	return nil
+}
+
+// ClearProbeOverride removes an override set with OverrideProbe.
+func (m *Manager) ClearProbeOverride(probe Probe) {
+	m.stateMx.Lock()
+	defer m.stateMx.Unlock()
+
+	o := m.probeOverrides[string(probe)]
+	if m.clearOverride(&m.probeOverrides, string(probe), nil) {
+		m.liftProbeOverride(string(probe), o)
+	}
+}
+
+// setOverride stores an override, replacing any existing one, and arranges
+// for it to expire. The caller must hold stateMx.
+func (m *Manager) setOverride(overrides *map[string]*override, key string, o *override, ttl time.Duration) {
+	m.clearOverride(overrides, key, nil)
+	if *overrides == nil {
+		*overrides = make(map[string]*override)
+	}
+	if ttl > 0 {
+		o.expires = time.Now().Add(ttl)
+		o.timer = time.AfterFunc(ttl, func() {
+			m.expireOverride(overrides, key, o)
+		})
+	}
+	(*overrides)[key] = o
+}
+
+// clearOverride removes an override, if it is still the one given (or any
+// override, if o is nil). Returns true if one was removed. The caller must
+// hold stateMx.
+func (m *Manager) clearOverride(overrides *map[string]*override, key string, o *override) bool {
+	current, ok := (*overrides)[key]
+	if !ok || (o != nil && current != o) {
+		return false
+	}
+	if current.timer != nil {
+		current.timer.Stop()
+	}
+	delete(*overrides, key)
+	return true
+}
+
+// expireOverride lifts an override whose ttl has elapsed.
+func (m *Manager) expireOverride(overrides *map[string]*override, key string, o *override) {
+	m.stateMx.Lock()
+	defer m.stateMx.Unlock()
+
+	if !m.clearOverride(overrides, key, o) {
+		return
+	}
+	m.logger().Info("override expired", "name", key, "reason", o.reason)
+	if overrides == &m.probeOverrides {
+		m.liftProbeOverride(key, o)
+		return
+	}
+	m.republish(key)
+}
+
+// logger returns the Logger, or a no-op logger if Run has not defaulted it
+// yet, as when an override expires before the manager runs. The caller must
+// hold stateMx.
+func (m *Manager) logger() health.Logger {
+	if m.Logger == nil {
+		return health.NoOpLogger{}
+	}
+	return m.Logger
+}
+
+// republish reports a check's latest real result again, with any override
+// applied, and re-evaluates the probes. The caller must hold stateMx.
+func (m *Manager) republish(name string) {
+	if !m.running() {
+		return
+	}
+	r, ok := m.checkResults.Get(name)
+	if !ok || r.actual == nil {
+		return
+	}
+	m.publishResult(m.runCtx, r, r)
+	m.evaluateFitness(m.runCtx)
+}
+
+// relayProbeOverride tells reporters implementing [health.OverrideReporter]
+// about a probe override and re-evaluates the probes. The caller must hold
+// stateMx.
+func (m *Manager) relayProbeOverride(probe, reason string) {
+	if !m.running() {
+		return
+	}
+	ctx := m.runCtx
+	m.reporters.Each(func(_ string, reporter health.Reporter) bool {
+		if or, ok := reporter.(health.OverrideReporter); ok {
+			or.SetProbeOverride(ctx, probe, reason)
+		}
+		return true
+	})
+	m.evaluateFitness(ctx)
+}
+
+// liftProbeOverride relays the removal of a probe override. If the probes
+// are not being evaluated yet, the probe goes back to its value from before
+// the override. The caller must hold stateMx.
+func (m *Manager) liftProbeOverride(probe string, o *override) {
+	if m.running() && (atomic.LoadUint32(&m.allChecksRan) == 0 || atomic.LoadUint32(&m.startupDone) == 0) {
+		m.setProbe(m.runCtx, probe, o.prior)
+	}
+	m.relayProbeOverride(probe, "")
+}
+
+// applyPendingProbeOverrides sets overridden probes while the check results
+// are not yet aggregated, as when a check has not reported or startup is
+// pending. The caller must hold stateMx.
+func (m *Manager) applyPendingProbeOverrides(ctx context.Context) {
+	for probe, o := range m.probeOverrides {
+		m.setProbe(ctx, probe, o.value)
+	}
+}
+
+// setProbe sets liveness or readiness regardless of check results.
+func (m *Manager) setProbe(ctx context.Context, probe string, value bool) {
+	switch Probe(probe) {
+	case ProbeLiveness:
+		_ = m.setLive(ctx, value)
+	case ProbeReadiness:
+		_ = m.storeReady(ctx, value)
+	}
+}
+
+// probeValue returns the current value of liveness or readiness or, before
+// Run, the value Run starts it with.
+func (m *Manager) probeValue(probe string) bool {
+	if !m.running() {
+		return Probe(probe) == ProbeLiveness
+	}
+	if Probe(probe) == ProbeLiveness {
+		return m.isLive()
+	}
+	return m.isReady()
+}
+
+// applyProbeOverrides replaces the aggregated liveness and readiness with
+// any overridden values. The caller must hold stateMx.
+func (m *Manager) applyProbeOverrides(live, ready bool) (bool, bool) {
+	if o, ok := m.probeOverrides[string(ProbeLiveness)]; ok {
+		live = o.value
+	}
+	if o, ok := m.probeOverrides[string(ProbeReadiness)]; ok {
+		ready = o.value
+	}
+	return live, ready
+}
+
+// seedProbeOverrides tells a newly added reporter about active probe
+// overrides. The caller must hold stateMx.
+func (m *Manager) seedProbeOverrides(ctx context.Context, r health.Reporter) {
+	or, ok := r.(health.OverrideReporter)
+	if !ok {
+		return
+	}
+	for probe, o := range m.probeOverrides {
+		or.SetProbeOverride(ctx, probe, o.reason)
+	}
+}
@@ -0,0 +1,211 @@
+package std_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/schigh/health/v2"
+	"github.com/schigh/health/v2/manager/std"
+	"github.com/schigh/health/v2/reporter/test"
+)
+
+func TestOverrideCheck(t *testing.T) {
+	mgr := &std.Manager{}
+	rpt := &test.Reporter{}
+
+	// a one-time check never reports again, so the real result must be
+	// restored from the last one seen
+	_ = mgr.AddCheck("db", NewMockChecker(&health.CheckResult{
+		Status: health.StatusUnhealthy,
+		Error:  errors.New("maintenance"),
+	}), health.WithReadinessImpact())
+	_ = mgr.AddCheck("api", NewMockChecker(&health.CheckResult{Status: health.StatusHealthy}))
+	_ = mgr.AddReporter("test", rpt)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_ = mgr.Run(ctx)
+
+	waitFor(t, 2*time.Second, func() bool {
+		hc := rpt.Report().HealthChecks["db"]
+		return hc != nil && hc.Status == health.StatusUnhealthy
+	})
+
+	if err := mgr.OverrideCheck("db", health.StatusHealthy, "planned failover", 0); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, 2*time.Second, func() bool {
+		return rpt.Report().IsReady
+	})
+	hc := rpt.Report().HealthChecks["db"]
+	if hc.Status != health.StatusHealthy || hc.Error != nil {
+		t.Fatalf("expected overridden healthy result, got %+v", hc)
+	}
+	if hc.Metadata["override"] != "planned failover" || hc.Metadata["actualStatus"] != "unhealthy" {
+		t.Fatalf("unexpected override metadata: %v", hc.Metadata)
+	}
+
+	mgr.ClearCheckOverride("db")
+	waitFor(t, 2*time.Second, func() bool {
+		return !rpt.Report().IsReady
+	})
+	if _, ok := rpt.Report().HealthChecks["db"].Metadata["override"]; ok {
+		t.Fatal("expected override metadata to be gone after clearing")
+	}
+
+	if err := mgr.OverrideCheck("missing", health.StatusHealthy, "", 0); err == nil {
+		t.Fatal("expected error overriding an unknown check")
+	}
+
+	_ = mgr.Stop(ctx)
+}
+
+func TestOverrideCheck_Expires(t *testing.T) {
+	mgr := &std.Manager{}
+	rpt := &test.Reporter{}
+
+	_ = mgr.AddCheck("db", NewMockChecker(&health.CheckResult{Status: health.StatusHealthy}))
+	_ = mgr.AddReporter("test", rpt)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_ = mgr.Run(ctx)
+
+	waitFor(t, 2*time.Second, func() bool {
+		return rpt.Report().HealthChecks["db"] != nil
+	})
+
+	_ = mgr.OverrideCheck("db", health.StatusSkipped, "migration", 100*time.Millisecond)
+	waitFor(t, 2*time.Second, func() bool {
+		hc := rpt.Report().HealthChecks["db"]
+		return hc.Status == health.StatusSkipped && hc.Metadata["overrideExpires"] != ""
+	})
+	waitFor(t, 2*time.Second, func() bool {
+		return rpt.Report().HealthChecks["db"].Status == health.StatusHealthy
+	})
+
+	_ = mgr.Stop(ctx)
+}
+
+func TestOverrideProbe(t *testing.T) {
+	mgr := &std.Manager{}
+	rpt := &test.Reporter{}
+
+	_ = mgr.AddCheck("db", NewMockChecker(&health.CheckResult{Status: health.StatusHealthy}), health.WithReadinessImpact())
+	_ = mgr.AddReporter("test", rpt)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_ = mgr.Run(ctx)
+
+	waitFor(t, 2*time.Second, func() bool {
+		return rpt.Report().IsReady
+	})
+
+	if err := mgr.OverrideProbe(std.ProbeReadiness, false, "maintenance window", 200*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, 2*time.Second, func() bool {
+		return !rpt.Report().IsReady
+	})
+	if got := rpt.Report().ProbeOverrides["readiness"]; got != "maintenance window" {
+		t.Fatalf("expected readiness override reason, got %q", got)
+	}
+
+	// expiry restores the real readiness
+	waitFor(t, 2*time.Second, func() bool {
+		return rpt.Report().IsReady
+	})
+	if _, ok := rpt.Report().ProbeOverrides["readiness"]; ok {
+		t.Fatal("expected readiness override to be lifted")
+	}
+
+	if err := mgr.OverrideProbe("startup", true, "", 0); err == nil {
+		t.Fatal("expected error overriding an unsupported probe")
+	}
+
+	_ = mgr.Stop(ctx)
+}
+
+func TestOverrideProbe_UnreportedCheck(t *testing.T) {
+	mgr := &std.Manager{}
+	rpt := &test.Reporter{}
+
+	// a passive check that never reports holds back the aggregation
+	if _, err := mgr.AddPassiveCheck("queue", 0, health.WithLivenessImpact(), health.WithReadinessImpact()); err != nil {
+		t.Fatal(err)
+	}
+	_ = mgr.AddReporter("test", rpt)
+
+	if err := mgr.OverrideProbe(std.ProbeLiveness, false, "maintenance", 0); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_ = mgr.Run(ctx)
+
+	waitFor(t, 2*time.Second, func() bool {
+		return !rpt.Report().IsLive
+	})
+	mgr.ClearProbeOverride(std.ProbeLiveness)
+	waitFor(t, 2*time.Second, func() bool {
+		return rpt.Report().IsLive
+	})
+
+	if err := mgr.OverrideProbe(std.ProbeReadiness, true, "cutover", 0); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, 2*time.Second, func() bool {
+		return rpt.Report().IsReady
+	})
+	mgr.ClearProbeOverride(std.ProbeReadiness)
+	waitFor(t, 2*time.Second, func() bool {
+		return !rpt.Report().IsReady
+	})
+
+	_ = mgr.Stop(ctx)
+}
+
+func TestOverride_ExpiresBeforeRun(t *testing.T) {
+	mgr := &std.Manager{}
+	_ = mgr.AddCheck("db", NewMockChecker(&health.CheckResult{Status: health.StatusHealthy}))
+
+	if err := mgr.OverrideCheck("db", health.StatusSkipped, "maintenance", 10*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if err := mgr.OverrideProbe(std.ProbeReadiness, false, "maintenance", 10*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	// expiring without a Logger must not panic
+	time.Sleep(50 * time.Millisecond)
+}
+
+func TestOverride_RemovedWithCheck(t *testing.T) {
+	mgr := &std.Manager{}
+	rpt := &test.Reporter{}
+	_ = mgr.AddCheck("db", NewMockChecker(&health.CheckResult{Status: health.StatusHealthy}))
+	_ = mgr.AddReporter("test", rpt)
+
+	if err := mgr.OverrideCheck("db", health.StatusUnhealthy, "maintenance", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if err := mgr.RemoveCheck("db"); err != nil {
+		t.Fatal(err)
+	}
+	_ = mgr.AddCheck("db", NewMockChecker(&health.CheckResult{Status: health.StatusHealthy}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_ = mgr.Run(ctx)
+
+	waitFor(t, 2*time.Second, func() bool {
+		hc := rpt.Report().HealthChecks["db"]
+		return hc != nil && hc.Status == health.StatusHealthy
+	})
+	if _, ok := rpt.Report().HealthChecks["db"].Metadata["override"]; ok {
+		t.Fatal("expected a re-added check not to inherit the removed check's override")
+	}
+}
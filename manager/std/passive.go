@@ -0,0 +1,158 @@
+package std
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/schigh/health/v2"
+)
+
+// PassiveCheck is a health check whose status is pushed by the application,
+// rather than pulled by the manager on a schedule. It is created with
+// [Manager.AddPassiveCheck]. Every status update counts as a heartbeat; if a
+// staleness window is set and no update arrives within it, the check is
+// reported unhealthy until the next update.
+//
+// The Set methods never block, so they are safe to call from hot paths such as
+// message consumers and stream handlers.
+type PassiveCheck struct {
+	name       string
+	staleAfter time.Duration
+	updates    chan struct{}
+
+	mu   sync.Mutex
+	last *health.CheckResult
+}
+
+// AddPassiveCheck adds a named push-style health check to the manager and
+// returns the handle used to update it. The check has no status, and holds
+// readiness, until the first update. If staleAfter is greater than zero, the
+// check is reported unhealthy when no update has arrived within that window.
+// Options are the same as for [Manager.AddCheck]; scheduling options are
+// ignored.
+func (m *Manager) AddPassiveCheck(name string, staleAfter time.Duration, opts ...health.AddCheckOption) (*PassiveCheck, error) {
+	p := &PassiveCheck{
+		name:       name,
+		staleAfter: staleAfter,
+		updates:    make(chan struct{}, 1),
+	}
+	if err := m.AddCheck(name, p, opts...); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// SetHealthy reports the check as healthy.
+func (p *PassiveCheck) SetHealthy() {
+	p.set(health.StatusHealthy, nil, nil)
+}
+
+// SetDegraded reports the check as degraded, with the reason recorded in the
+// result Metadata.
+func (p *PassiveCheck) SetDegraded(reason string) {
+	p.set(health.StatusDegraded, nil, map[string]string{"reason": reason})
+}
+
+// SetUnhealthy reports the check as unhealthy with the given error.
+func (p *PassiveCheck) SetUnhealthy(err error) {
+	if err == nil {
+		err = errors.New("unhealthy")
+	}
+	p.set(health.StatusUnhealthy, err, nil)
+}
+
+// Check satisfies health.Checker. It returns the most recently pushed status,
+// or an unhealthy result if none has been pushed or the last one is stale.
+func (p *PassiveCheck) Check(_ context.Context) *health.CheckResult {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	if p.last == nil {
+		return &health.CheckResult{
+			Name:       p.name,
+			Status:     health.StatusUnhealthy,
+			Error:      errors.New("no status reported"),
+			ErrorSince: now,
+			Timestamp:  now,
+		}
+	}
+
+	if p.staleAfter > 0 && now.Sub(p.last.Timestamp) >= p.staleAfter {
+		return &health.CheckResult{
+			Name:       p.name,
+			Status:     health.StatusUnhealthy,
+			Error:      fmt.Errorf("no status reported in %s", p.staleAfter),
+			ErrorSince: p.last.Timestamp.Add(p.staleAfter),
+			Timestamp:  now,
+			Metadata:   map[string]string{"lastUpdate": p.last.Timestamp.Format(time.RFC3339)},
+		}
+	}
+
+	out := *p.last
+	return &out
+}
+
+// set records a new status and wakes the dispatch goroutine without blocking.
+func (p *PassiveCheck) set(status health.Status, err error, metadata map[string]string) {
+	now := time.Now()
+	hc := &health.CheckResult{
+		Name:      p.name,
+		Status:    status,
+		Error:     err,
+		Metadata:  metadata,
+		Timestamp: now,
+	}
+
+	p.mu.Lock()
+	if err != nil {
+		// keep the start of an ongoing error state
+		hc.ErrorSince = now
+		if p.last != nil && p.last.Error != nil {
+			hc.ErrorSince = p.last.ErrorSince
+		}
+	}
+	p.last = hc
+	p.mu.Unlock()
+
+	select {
+	case p.updates <- struct{}{}:
+	default:
+	}
+}
+
+// dispatchPassiveCheck relays each pushed status to the manager, and reports
+// the check as stale when no update arrives within its staleness window.
+func (m *Manager) dispatchPassiveCheck(ctx context.Context, name string, w *wrapper, p *PassiveCheck) {
+	var (
+		timer *time.Timer
+		stale <-chan time.Time
+	)
+	if p.staleAfter > 0 {
+		timer = time.NewTimer(p.staleAfter)
+		defer timer.Stop()
+		stale = timer.C
+	}
+
+	m.Logger.Debug("running passive checker", "checker", name, "staleAfter", p.staleAfter)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-p.updates:
+			if timer != nil {
+				timer.Reset(p.staleAfter)
+			}
+		case <-stale:
+		}
+
+		hc := p.Check(ctx)
+		hc = applyCheckOptions(hc, name, &w.opts)
+		if !m.send(ctx, w, hc) {
+			return
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package std_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/schigh/health/v2"
+	"github.com/schigh/health/v2/manager/std"
+	"github.com/schigh/health/v2/reporter/test"
+)
+
+func TestPassiveCheck_PushedStatus(t *testing.T) {
+	mgr := &std.Manager{}
+	rpt := &test.Reporter{}
+
+	consumer, err := mgr.AddPassiveCheck("kafka", 0, health.WithReadinessImpact())
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = mgr.AddReporter("test", rpt)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	_ = mgr.Run(ctx)
+
+	consumer.SetHealthy()
+	waitFor(t, 2*time.Second, func() bool {
+		return rpt.Report().IsReady
+	})
+
+	consumer.SetDegraded("lagging")
+	waitFor(t, 2*time.Second, func() bool {
+		hc := rpt.Report().HealthChecks["kafka"]
+		return hc != nil && hc.Status == health.StatusDegraded
+	})
+	if reason := rpt.Report().HealthChecks["kafka"].Metadata["reason"]; reason != "lagging" {
+		t.Fatalf("expected degraded reason 'lagging', got %q", reason)
+	}
+
+	consumer.SetUnhealthy(errors.New("rebalance failed"))
+	waitFor(t, 2*time.Second, func() bool {
+		return !rpt.Report().IsReady
+	})
+	hc := rpt.Report().HealthChecks["kafka"]
+	if hc.Error == nil || hc.Error.Error() != "rebalance failed" {
+		t.Fatalf("expected pushed error, got %v", hc.Error)
+	}
+
+	cancel()
+	_ = mgr.Stop(ctx)
+}
+
+func TestPassiveCheck_HoldsReadinessUntilFirstUpdate(t *testing.T) {
+	mgr := &std.Manager{}
+	rpt := &test.Reporter{}
+
+	_, _ = mgr.AddPassiveCheck("stream", 0, health.WithReadinessImpact())
+	_ = mgr.AddCheck("other", health.CheckerFunc(func(_ context.Context) *health.CheckResult {
+		return &health.CheckResult{Name: "other", Status: health.StatusHealthy}
+	}))
+	_ = mgr.AddReporter("test", rpt)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	_ = mgr.Run(ctx)
+
+	waitFor(t, 2*time.Second, func() bool {
+		return rpt.Report().HealthChecks["other"] != nil
+	})
+	time.Sleep(50 * time.Millisecond)
+	if rpt.Report().IsReady {
+		t.Fatal("expected readiness to wait for the passive check's first update")
+	}
+
+	cancel()
+	_ = mgr.Stop(ctx)
+}
+
+func TestPassiveCheck_Stale(t *testing.T) {
+	mgr := &std.Manager{}
+	rpt := &test.Reporter{}
+
+	heartbeat, _ := mgr.AddPassiveCheck("worker", 50*time.Millisecond, health.WithReadinessImpact())
+	_ = mgr.AddReporter("test", rpt)
+
+	// updates before Run are delivered once the manager starts
+	heartbeat.SetHealthy()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	_ = mgr.Run(ctx)
+
+	waitFor(t, 2*time.Second, func() bool {
+		return rpt.Report().IsReady
+	})
+
+	// stop heartbeating
+	waitFor(t, 2*time.Second, func() bool {
+		return !rpt.Report().IsReady
+	})
+	hc := rpt.Report().HealthChecks["worker"]
+	if hc.Status != health.StatusUnhealthy {
+		t.Fatalf("expected stale check to be unhealthy, got %s", hc.Status)
+	}
+
+	// resume heartbeating
+	heartbeat.SetHealthy()
+	waitFor(t, 2*time.Second, func() bool {
+		return rpt.Report().IsReady
+	})
+
+	cancel()
+	_ = mgr.Stop(ctx)
+}
@@ -0,0 +1,130 @@
+package std
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/schigh/health/v2"
+)
+
+// Check execution priorities for the worker pool, highest first.
+const (
+	priorityStartup = iota
+	priorityLiveness
+	priorityReadiness
+	priorityOther
+	numPriorities
+)
+
+// checkPriority returns the pool priority of a check: startup-affecting
+// checks first, then liveness, then readiness, then everything else.
+func checkPriority(opts *health.AddCheckOptions) int {
+	switch {
+	case opts.AffectsStartup:
+		return priorityStartup
+	case opts.AffectsLiveness:
+		return priorityLiveness
+	case opts.AffectsReadiness:
+		return priorityReadiness
+	default:
+		return priorityOther
+	}
+}
+
+// workerPool is a semaphore limiting concurrent check executions. Waiters
+// are served in priority order, first come first served within a priority.
+type workerPool struct {
+	mu      sync.Mutex
+	size    int
+	active  int
+	waiters [numPriorities][]chan struct{}
+}
+
+func newWorkerPool(size int) *workerPool {
+	return &workerPool{size: size}
+}
+
+// acquire waits for a free slot. Returns false if the context is done first.
+func (p *workerPool) acquire(ctx context.Context, priority int) bool {
+	p.mu.Lock()
+	if p.active < p.size && !p.hasWaiters() {
+		p.active++
+		p.mu.Unlock()
+		return true
+	}
+	ch := make(chan struct{})
+	p.waiters[priority] = append(p.waiters[priority], ch)
+	p.mu.Unlock()
+
+	select {
+	case <-ch:
+		return true
+	case <-ctx.Done():
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if i := slices.Index(p.waiters[priority], ch); i >= 0 {
+			p.waiters[priority] = slices.Delete(p.waiters[priority], i, i+1)
+			return false
+		}
+		// the slot was handed over as the context was cancelled; pass it on
+		p.releaseLocked()
+		return false
+	}
+}
+
+// release frees a slot, handing it to the highest-priority waiter if there
+// is one.
+func (p *workerPool) release() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.releaseLocked()
+}
+
+func (p *workerPool) releaseLocked() {
+	for i := range p.waiters {
+		if len(p.waiters[i]) > 0 {
+			ch := p.waiters[i][0]
+			p.waiters[i] = p.waiters[i][1:]
+			close(ch)
+			return
+		}
+	}
+	p.active--
+}
+
+func (p *workerPool) hasWaiters() bool {
+	for i := range p.waiters {
+		if len(p.waiters[i]) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// pooledCheck runs a check once a worker pool slot is free, recording how
+// long it waited in the result's Metadata under "queueWait". Returns nil if
+// the context is done before a slot is free.
+func (m *Manager) pooledCheck(ctx context.Context, name string, w *wrapper, run func() *health.CheckResult) *health.CheckResult {
+	start := time.Now()
+	if !m.pool.acquire(ctx, checkPriority(&w.opts)) {
+		return nil
+	}
+	defer m.pool.release()
+
+	wait := time.Since(start)
+	if wait > queueWaitWarning {
+		m.Logger.Warn("check waited for a free worker", "checker", name, "wait", wait)
+	}
+
+	hc := run()
+	if hc == nil {
+		return nil
+	}
+	return withMetadata(hc, "queueWait", wait.String())
+}
+
+// queueWaitWarning is how long a check can wait for a worker before the
+// wait is logged as a warning.
+const queueWaitWarning = time.Second
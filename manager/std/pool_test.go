@@ -0,0 +1,86 @@
+package std
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWorkerPool_Priority(t *testing.T) {
+	p := newWorkerPool(1)
+	ctx := context.Background()
+	if !p.acquire(ctx, priorityOther) {
+		t.Fatal("expected a free slot")
+	}
+
+	var mu sync.Mutex
+	var order []int
+	var wg sync.WaitGroup
+	for i, prio := range []int{priorityOther, priorityReadiness, priorityStartup, priorityLiveness} {
+		wg.Add(1)
+		go func(prio int) {
+			defer wg.Done()
+			if !p.acquire(ctx, prio) {
+				t.Error("expected to acquire a slot")
+				return
+			}
+			mu.Lock()
+			order = append(order, prio)
+			mu.Unlock()
+			p.release()
+		}(prio)
+		// queue in a known order
+		waitQueued(t, p, i+1)
+	}
+
+	p.release()
+	wg.Wait()
+
+	want := []int{priorityStartup, priorityLiveness, priorityReadiness, priorityOther}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("expected order %v, got %v", want, order)
+		}
+	}
+}
+
+func TestWorkerPool_CancelWhileWaiting(t *testing.T) {
+	p := newWorkerPool(1)
+	if !p.acquire(context.Background(), priorityOther) {
+		t.Fatal("expected a free slot")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if p.acquire(ctx, priorityStartup) {
+		t.Fatal("expected acquire to give up when the context is done")
+	}
+	if p.hasWaiters() {
+		t.Fatal("expected cancelled waiter to be removed from the queue")
+	}
+
+	p.release()
+	if !p.acquire(context.Background(), priorityOther) {
+		t.Fatal("expected the released slot to be free")
+	}
+}
+
+// waitQueued waits until the pool has n waiters.
+func waitQueued(t *testing.T, p *workerPool, n int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		p.mu.Lock()
+		var queued int
+		for i := range p.waiters {
+			queued += len(p.waiters[i])
+		}
+		p.mu.Unlock()
+		if queued >= n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("expected %d queued waiters", n)
+}
@@ -0,0 +1,85 @@
+package std
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+
+	"github.com/schigh/health/v2"
+)
+
+// sleep waits for d or until the context is done, whichever comes first.
+// Returns false if the context is done.
+func sleep(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
+
+// minInterval is the shortest wait between executions of an interval check,
+// however the backoff and jitter work out, so that a check never spins.
+const minInterval = time.Millisecond
+
+// checkInterval returns the interval of an interval check, defaulting one
+// that is not positive.
+func checkInterval(opts *health.AddCheckOptions) time.Duration {
+	if opts.Interval <= 0 {
+		return health.DefaultCheckInterval
+	}
+	return opts.Interval
+}
+
+// nextInterval returns how long an interval check waits before its next
+// execution, given how many times in a row it has failed.
+func nextInterval(opts *health.AddCheckOptions, failures int) time.Duration {
+	d := checkInterval(opts)
+	if failures > 0 && opts.Frequency&health.CheckWithBackoff == health.CheckWithBackoff {
+		d = backoffInterval(opts, failures)
+	}
+	if opts.Frequency&health.CheckWithJitter == health.CheckWithJitter {
+		d = jitter(d, opts.Jitter)
+	}
+	return max(d, minInterval)
+}
+
+// backoffInterval returns the wait after the given number of consecutive
+// failures: the initial backoff interval, multiplied once per further failure
+// and capped at the maximum.
+func backoffInterval(opts *health.AddCheckOptions, failures int) time.Duration {
+	d := opts.BackoffInterval
+	if d <= 0 {
+		d = checkInterval(opts)
+	}
+	multiplier := opts.BackoffMultiplier
+	if multiplier <= 0 {
+		multiplier = 2
+	}
+	// a shrinking backoff would eventually spin
+	multiplier = max(multiplier, 1)
+	maxInterval := opts.BackoffMaxInterval
+	if maxInterval <= 0 {
+		maxInterval = 10 * checkInterval(opts)
+	}
+
+	for i := 1; i < failures && d < maxInterval; i++ {
+		d = time.Duration(float64(d) * multiplier)
+	}
+	return min(d, maxInterval)
+}
+
+// jitter randomly lengthens or shortens d by up to fraction of d.
+func jitter(d time.Duration, fraction float64) time.Duration {
+	if fraction == 0 {
+		fraction = health.DefaultJitter
+	}
+	fraction = min(max(fraction, 0), 1)
+
+	//nolint:gosec // scheduling jitter does not need a cryptographic source
+	offset := (rand.Float64()*2 - 1) * fraction * float64(d)
+	return d + time.Duration(offset)
+}
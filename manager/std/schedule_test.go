@@ -0,0 +1,107 @@
+package std
+
+import (
+	"testing"
+	"time"
+
+	"github.com/schigh/health/v2"
+)
+
+func TestNextInterval_Plain(t *testing.T) {
+	opts := health.AddCheckOptions{Frequency: health.CheckAtInterval, Interval: time.Second}
+	if d := nextInterval(&opts, 3); d != time.Second {
+		t.Fatalf("expected the plain interval regardless of failures, got %s", d)
+	}
+}
+
+func TestNextInterval_NonPositive(t *testing.T) {
+	opts := health.AddCheckOptions{Frequency: health.CheckAtInterval}
+	if d := nextInterval(&opts, 0); d != health.DefaultCheckInterval {
+		t.Fatalf("expected a zero interval to default, got %s", d)
+	}
+
+	opts = health.AddCheckOptions{
+		Frequency:       health.CheckAtInterval | health.CheckWithBackoff,
+		Interval:        -time.Second,
+		BackoffInterval: time.Nanosecond,
+	}
+	if d := nextInterval(&opts, 1); d != minInterval {
+		t.Fatalf("expected a backoff below the minimum to be clamped, got %s", d)
+	}
+	if d := nextInterval(&opts, 100); d != 10*health.DefaultCheckInterval {
+		t.Fatalf("expected the backoff cap to follow the default interval, got %s", d)
+	}
+
+	opts = health.AddCheckOptions{
+		Frequency: health.CheckAtInterval | health.CheckWithJitter,
+		Interval:  time.Millisecond,
+		Jitter:    1,
+	}
+	for range 100 {
+		if d := nextInterval(&opts, 0); d < minInterval {
+			t.Fatalf("expected jitter to be clamped to the minimum, got %s", d)
+		}
+	}
+}
+
+func TestNextInterval_Backoff(t *testing.T) {
+	opts := health.AddCheckOptions{
+		Frequency:          health.CheckAtInterval | health.CheckWithBackoff,
+		Interval:           10 * time.Second,
+		BackoffInterval:    time.Second,
+		BackoffMultiplier:  2,
+		BackoffMaxInterval: 5 * time.Second,
+	}
+
+	tests := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{0, 10 * time.Second},
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{3, 4 * time.Second},
+		{4, 5 * time.Second},
+		{100, 5 * time.Second},
+	}
+	for _, tt := range tests {
+		if got := nextInterval(&opts, tt.failures); got != tt.want {
+			t.Errorf("failures=%d: expected %s, got %s", tt.failures, tt.want, got)
+		}
+	}
+}
+
+func TestNextInterval_BackoffDefaults(t *testing.T) {
+	opts := health.AddCheckOptions{
+		Frequency: health.CheckAtInterval | health.CheckWithBackoff,
+		Interval:  time.Second,
+	}
+	if got := nextInterval(&opts, 2); got != 2*time.Second {
+		t.Errorf("expected default backoff to double the interval, got %s", got)
+	}
+	if got := nextInterval(&opts, 50); got != 10*time.Second {
+		t.Errorf("expected default backoff to cap at 10x the interval, got %s", got)
+	}
+}
+
+func TestNextInterval_Jitter(t *testing.T) {
+	opts := health.AddCheckOptions{
+		Frequency: health.CheckAtInterval | health.CheckWithJitter,
+		Interval:  time.Second,
+		Jitter:    0.2,
+	}
+
+	var varied bool
+	for i := 0; i < 100; i++ {
+		d := nextInterval(&opts, 0)
+		if d < 800*time.Millisecond || d > 1200*time.Millisecond {
+			t.Fatalf("expected jittered interval within 20%% of 1s, got %s", d)
+		}
+		if d != time.Second {
+			varied = true
+		}
+	}
+	if !varied {
+		t.Fatal("expected jitter to vary the interval")
+	}
+}
@@ -0,0 +1,64 @@
+package std
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/schigh/health/v2"
+)
+
+// Snapshot is a point-in-time copy of a manager's state.
+type Snapshot struct {
+	Running bool
+	Live    bool
+	Ready   bool
+	Startup bool
+
+	// Checks holds the latest result of every check that has reported,
+	// keyed by check name.
+	Checks map[string]*health.CheckResult
+}
+
+// Snapshot returns the current liveness, readiness and startup state and the
+// latest result of every check. It is safe to call at any time, including
+// concurrently with running checks. The returned results are copies and may
+// be modified by the caller.
+func (m *Manager) Snapshot() Snapshot {
+	s := Snapshot{
+		Running: m.running(),
+		Live:    m.isLive(),
+		Ready:   m.isReady(),
+		Startup: m.isStartup(),
+		Checks:  make(map[string]*health.CheckResult),
+	}
+	m.checkResults.Each(func(name string, value result) bool {
+		if value.last != nil {
+			s.Checks[name] = copyResult(value.last)
+		}
+		return true
+	})
+	return s
+}
+
+// CheckStatus returns a copy of the latest result of the named check. It
+// returns false if there is no such check or it has not reported yet.
+func (m *Manager) CheckStatus(name string) (*health.CheckResult, bool) {
+	r, ok := m.checkResults.Get(name)
+	if !ok || r.last == nil {
+		return nil, false
+	}
+	return copyResult(r.last), true
+}
+
+// copyResult returns a deep copy of a check result.
+func copyResult(hc *health.CheckResult) *health.CheckResult {
+	out := *hc
+	out.DependsOn = slices.Clone(hc.DependsOn)
+	out.Metadata = maps.Clone(hc.Metadata)
+	if hc.History != nil {
+		h := *hc.History
+		h.Executions = slices.Clone(hc.History.Executions)
+		out.History = &h
+	}
+	return &out
+}
@@ -0,0 +1,62 @@
+package std_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/schigh/health/v2"
+	"github.com/schigh/health/v2/manager/std"
+	"github.com/schigh/health/v2/reporter/test"
+)
+
+func TestSnapshot(t *testing.T) {
+	mgr := &std.Manager{}
+	rpt := &test.Reporter{}
+
+	if s := mgr.Snapshot(); s.Running || len(s.Checks) != 0 {
+		t.Fatalf("expected empty snapshot before run, got %+v", s)
+	}
+
+	_ = mgr.AddCheck("db", health.CheckerFunc(func(_ context.Context) *health.CheckResult {
+		return &health.CheckResult{Name: "db", Status: health.StatusHealthy, Metadata: map[string]string{"pool": "ok"}}
+	}), health.WithReadinessImpact(), health.WithStartupImpact())
+	_ = mgr.AddReporter("test", rpt)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_ = mgr.Run(ctx)
+
+	waitFor(t, 2*time.Second, func() bool {
+		return mgr.Snapshot().Ready
+	})
+
+	s := mgr.Snapshot()
+	if !s.Running || !s.Live || !s.Startup {
+		t.Fatalf("expected running, live and started, got %+v", s)
+	}
+	hc := s.Checks["db"]
+	if hc == nil || hc.Status != health.StatusHealthy {
+		t.Fatalf("expected healthy db result, got %+v", hc)
+	}
+
+	// results are copies
+	hc.Status = health.StatusUnhealthy
+	hc.Metadata["pool"] = "mutated"
+	again, ok := mgr.CheckStatus("db")
+	if !ok {
+		t.Fatal("expected CheckStatus to find db")
+	}
+	if again.Status != health.StatusHealthy || again.Metadata["pool"] != "ok" {
+		t.Fatalf("snapshot mutation leaked into manager state: %+v", again)
+	}
+	if rpt.Report().HealthChecks["db"].Metadata["pool"] != "ok" {
+		t.Fatal("snapshot mutation leaked into reporter state")
+	}
+
+	if _, ok := mgr.CheckStatus("missing"); ok {
+		t.Fatal("expected CheckStatus to report unknown check as missing")
+	}
+
+	_ = mgr.Stop(ctx)
+}
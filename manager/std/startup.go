@@ -0,0 +1,102 @@
+package std
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/schigh/health/v2"
+)
+
+// markStartupDone records that startup is complete and, if a liveness grace
+// period is set, arranges for liveness to be re-evaluated when it ends. The
+// caller must hold stateMx.
+func (m *Manager) markStartupDone(ctx context.Context) {
+	atomic.StoreUint32(&m.startupDone, 1)
+	m.startupAt = time.Now()
+	m.setStartup(ctx, true)
+
+	if m.LivenessGracePeriod <= 0 {
+		return
+	}
+	go func() {
+		if !sleep(ctx, m.LivenessGracePeriod) {
+			return
+		}
+		m.stateMx.Lock()
+		defer m.stateMx.Unlock()
+		m.evaluateFitness(ctx)
+	}()
+}
+
+// inLivenessGrace reports whether liveness failures are still being
+// tolerated after startup. An operator's liveness override takes precedence
+// over the grace period. The caller must hold stateMx.
+func (m *Manager) inLivenessGrace() bool {
+	if _, overridden := m.probeOverrides[string(ProbeLiveness)]; overridden {
+		return false
+	}
+	return m.LivenessGracePeriod > 0 && time.Since(m.startupAt) < m.LivenessGracePeriod
+}
+
+// watchStartupDeadline reports a fatal error on the error channel if startup
+// has not completed within the startup deadline.
+func (m *Manager) watchStartupDeadline(ctx context.Context) {
+	if m.StartupDeadline <= 0 {
+		return
+	}
+	go func() {
+		if !sleep(ctx, m.StartupDeadline) {
+			return
+		}
+
+		m.stateMx.Lock()
+		defer m.stateMx.Unlock()
+
+		if !m.running() || atomic.LoadUint32(&m.startupDone) != 0 {
+			return
+		}
+
+		err := fmt.Errorf("%w.manager.std: startup did not complete within %s; waiting on %s",
+			health.ErrHealth, m.StartupDeadline, strings.Join(m.pendingStartupChecks(), ", "))
+		m.Logger.Error("startup deadline exceeded", "error", err)
+
+		if m.StartupDeadlineFailsLiveness {
+			_ = m.setLive(ctx, false)
+		}
+
+		select {
+		case m.errChan <- err:
+		default:
+		}
+	}()
+}
+
+// pendingStartupChecks returns the sorted names of the checks startup is
+// waiting on: startup checks that have not passed, or, if there are none,
+// checks that have not reported yet.
+func (m *Manager) pendingStartupChecks() []string {
+	var failing, unreported []string
+	m.checkers.Each(func(name string, w wrapper) bool {
+		r, ok := m.checkResults.Get(name)
+		switch {
+		case !ok:
+			unreported = append(unreported, name)
+		case w.opts.AffectsStartup && (r.failing || r.cancelLive || r.cancelReady):
+			failing = append(failing, name)
+		}
+		return true
+	})
+
+	pending := failing
+	for _, name := range unreported {
+		if w, _ := m.checkers.Get(name); w.opts.AffectsStartup || len(failing) == 0 {
+			pending = append(pending, name)
+		}
+	}
+	slices.Sort(pending)
+	return pending
+}
@@ -0,0 +1,133 @@
+package std_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/schigh/health/v2"
+	"github.com/schigh/health/v2/manager/std"
+	"github.com/schigh/health/v2/reporter/test"
+)
+
+func TestStartupDeadline(t *testing.T) {
+	mgr := &std.Manager{
+		StartupDeadline:              100 * time.Millisecond,
+		StartupDeadlineFailsLiveness: true,
+	}
+	rpt := &test.Reporter{}
+
+	_ = mgr.AddCheck("migrations", NewMockChecker(&health.CheckResult{
+		Status: health.StatusUnhealthy,
+		Error:  errors.New("pending"),
+	}), health.WithCheckFrequency(health.CheckAtInterval, 20*time.Millisecond, 0), health.WithStartupImpact())
+	_ = mgr.AddReporter("test", rpt)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	errs := mgr.Run(ctx)
+
+	select {
+	case err := <-errs:
+		if !errors.Is(err, health.ErrHealth) || !strings.Contains(err.Error(), "migrations") {
+			t.Fatalf("unexpected startup deadline error: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected a startup deadline error")
+	}
+	waitFor(t, time.Second, func() bool {
+		return !rpt.Report().IsLive
+	})
+
+	_ = mgr.Stop(ctx)
+}
+
+func TestStartupDeadline_Met(t *testing.T) {
+	mgr := &std.Manager{StartupDeadline: 100 * time.Millisecond}
+	rpt := &test.Reporter{}
+
+	_ = mgr.AddCheck("migrations", NewMockChecker(&health.CheckResult{Status: health.StatusHealthy}), health.WithStartupImpact())
+	_ = mgr.AddReporter("test", rpt)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	errs := mgr.Run(ctx)
+
+	select {
+	case err := <-errs:
+		t.Fatalf("expected no error once startup completed, got %v", err)
+	case <-time.After(200 * time.Millisecond):
+	}
+	if !rpt.Report().IsStartup {
+		t.Fatal("expected startup to be complete")
+	}
+
+	_ = mgr.Stop(ctx)
+}
+
+func TestLivenessGracePeriod(t *testing.T) {
+	mgr := &std.Manager{LivenessGracePeriod: 300 * time.Millisecond}
+	rpt := &test.Reporter{}
+
+	var failing atomic.Bool
+	failing.Store(true)
+	_ = mgr.AddCheck("deadlock", health.CheckerFunc(func(_ context.Context) *health.CheckResult {
+		if failing.Load() {
+			return &health.CheckResult{Status: health.StatusUnhealthy, Error: errors.New("stuck")}
+		}
+		return &health.CheckResult{Status: health.StatusHealthy}
+	}), health.WithCheckFrequency(health.CheckAtInterval, 20*time.Millisecond, 0), health.WithLivenessImpact())
+	_ = mgr.AddReporter("test", rpt)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_ = mgr.Run(ctx)
+
+	waitFor(t, time.Second, func() bool {
+		hc := rpt.Report().HealthChecks["deadlock"]
+		return hc != nil && hc.Status == health.StatusUnhealthy
+	})
+	if !rpt.Report().IsLive {
+		t.Fatal("expected liveness failure to be tolerated during the grace period")
+	}
+
+	waitFor(t, 2*time.Second, func() bool {
+		return !rpt.Report().IsLive
+	})
+
+	_ = mgr.Stop(ctx)
+}
+
+func TestLivenessGracePeriod_ProbeOverride(t *testing.T) {
+	mgr := &std.Manager{LivenessGracePeriod: time.Hour}
+	rpt := &test.Reporter{}
+
+	_ = mgr.AddCheck("deadlock", NewMockChecker(&health.CheckResult{Status: health.StatusUnhealthy, Error: errors.New("stuck")}),
+		health.WithCheckFrequency(health.CheckAtInterval, 20*time.Millisecond, 0), health.WithLivenessImpact())
+	_ = mgr.AddReporter("test", rpt)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_ = mgr.Run(ctx)
+
+	waitFor(t, time.Second, func() bool {
+		hc := rpt.Report().HealthChecks["deadlock"]
+		return hc != nil && hc.Status == health.StatusUnhealthy
+	})
+	if !rpt.Report().IsLive {
+		t.Fatal("expected liveness failure to be tolerated during the grace period")
+	}
+
+	// an explicit override wins over the grace period
+	if err := mgr.OverrideProbe(std.ProbeLiveness, false, "restart requested", 0); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, time.Second, func() bool {
+		return !rpt.Report().IsLive
+	})
+
+	_ = mgr.Stop(ctx)
+}
@@ -120,6 +120,16 @@ func (r *Reporter) UpdateHealthChecks(_ context.Context, m map[string]*health.Ch
 	}
 }
 
+// RemoveHealthChecks implements health.CheckRemover.
+func (r *Reporter) RemoveHealthChecks(_ context.Context, names []string) {
+	r.hcMx.Lock()
+	defer r.hcMx.Unlock()
+
+	for _, name := range names {
+		delete(r.hcs, name)
+	}
+}
+
 // Check implements grpc_health_v1.HealthServer.
 func (r *Reporter) Check(_ context.Context, req *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
 	svc := req.GetService()
@@ -23,6 +23,10 @@ const (
 	ReadinessNegativeResponseCode    = http.StatusServiceUnavailable
 	StartupAffirmativeResponseCode   = http.StatusOK
 	StartupNegativeResponseCode      = http.StatusServiceUnavailable
+
+	// OverrideHeader carries the reason for an operator override of the
+	// liveness or readiness probe.
+	OverrideHeader = "X-Health-Override"
 )
 
 type Reporter struct {
@@ -30,9 +34,13 @@ type Reporter struct {
 	live    uint32
 	ready   uint32
 	startup uint32
-	hcCache *cache
+	// notReady counts consecutive readiness probes answered as not ready
+	notReady uint32
+	hcCache  *cache
 	hcMx    sync.RWMutex
 	hcs         map[string]*health.CheckResult
+	ovMx        sync.RWMutex
+	overrides   map[string]string
 	server      *http.Server
 	logger      health.Logger
 	serviceName string
@@ -166,7 +174,38 @@ func (r *Reporter) SetReadiness(_ context.Context, b bool) {
 	if b {
 		v = 1
 	}
-	_ = atomic.SwapUint32(&r.ready, v)
+	if old := atomic.SwapUint32(&r.ready, v); old != v {
+		atomic.StoreUint32(&r.notReady, 0)
+	}
+}
+
+// SetProbeOverride implements health.OverrideReporter.
+func (r *Reporter) SetProbeOverride(_ context.Context, probe, reason string) {
+	r.ovMx.Lock()
+	defer r.ovMx.Unlock()
+
+	if reason == "" {
+		delete(r.overrides, probe)
+		return
+	}
+	if r.overrides == nil {
+		r.overrides = make(map[string]string)
+	}
+	r.overrides[probe] = reason
+}
+
+// probeOverride returns the reason for an override of the given probe, if
+// there is one.
+func (r *Reporter) probeOverride(probe string) (string, bool) {
+	r.ovMx.RLock()
+	defer r.ovMx.RUnlock()
+	reason, ok := r.overrides[probe]
+	return reason, ok
+}
+
+// NotReadyProbes implements health.ReadinessProber.
+func (r *Reporter) NotReadyProbes() int {
+	return int(atomic.LoadUint32(&r.notReady))
 }
 
 func (r *Reporter) SetStartup(_ context.Context, b bool) {
@@ -197,6 +236,17 @@ func (r *Reporter) UpdateHealthChecks(_ context.Context, m map[string]*health.Ch
 	r.cacheHealthChecks()
 }
 
+// RemoveHealthChecks implements health.CheckRemover.
+func (r *Reporter) RemoveHealthChecks(_ context.Context, names []string) {
+	r.hcMx.Lock()
+	for _, name := range names {
+		delete(r.hcs, name)
+	}
+	r.hcMx.Unlock()
+
+	r.cacheHealthChecks()
+}
+
 func (r *Reporter) reportLiveness(rw http.ResponseWriter, rq *http.Request) {
 	if atomic.LoadUint32(&r.running) == 0 {
 		r.reportNotRunning(rw, rq)
@@ -214,6 +264,9 @@ func (r *Reporter) reportLiveness(rw http.ResponseWriter, rq *http.Request) {
 	}
 
 	data := r.hcCache.read()
+	if reason, ok := r.probeOverride("liveness"); ok {
+		rw.Header().Set(OverrideHeader, reason)
+	}
 	rw.Header().Set("Content-Type", "application/json")
 	rw.WriteHeader(statusCode)
 	_, _ = rw.Write(data)
@@ -233,9 +286,15 @@ func (r *Reporter) reportReadiness(rw http.ResponseWriter, rq *http.Request) {
 	statusCode := ReadinessAffirmativeResponseCode
 	if atomic.LoadUint32(&r.ready) == 0 {
 		statusCode = ReadinessNegativeResponseCode
+		atomic.AddUint32(&r.notReady, 1)
+	} else {
+		atomic.StoreUint32(&r.notReady, 0)
 	}
 
 	data := r.hcCache.read()
+	if reason, ok := r.probeOverride("readiness"); ok {
+		rw.Header().Set(OverrideHeader, reason)
+	}
 	rw.Header().Set("Content-Type", "application/json")
 	rw.WriteHeader(statusCode)
 	_, _ = rw.Write(data)
@@ -264,7 +323,7 @@ func (r *Reporter) reportStartup(rw http.ResponseWriter, rq *http.Request) {
 }
 
 // reportVerbose returns a K8s-style verbose health check listing.
-// Format: [+]name ok / [-]name failed: error
+// Format: [+]name ok / [+]name skipped: reason / [-]name failed: error
 // Supports ?exclude=name to omit specific checks.
 func (r *Reporter) reportVerbose(rw http.ResponseWriter, rq *http.Request) {
 	r.hcMx.RLock()
@@ -288,6 +347,8 @@ func (r *Reporter) reportVerbose(rw http.ResponseWriter, rq *http.Request) {
 				errMsg = hc.Error.Error()
 			}
 			fmt.Fprintf(&buf, "[-]%s failed: %s\n", name, errMsg)
+		} else if hc.Status == health.StatusSkipped {
+			fmt.Fprintf(&buf, "[+]%s skipped: %s\n", name, hc.Error)
 		} else {
 			fmt.Fprintf(&buf, "[+]%s ok\n", name)
 		}
@@ -343,6 +404,9 @@ func (r *Reporter) reportIndividualCheck(rw http.ResponseWriter, rq *http.Reques
 			errMsg = hc.Error.Error()
 		}
 		fmt.Fprintf(rw, "[-]%s failed: %s\n", checkName, errMsg)
+	} else if hc.Status == health.StatusSkipped {
+		rw.WriteHeader(http.StatusOK)
+		fmt.Fprintf(rw, "[+]%s skipped: %s\n", checkName, hc.Error)
 	} else {
 		rw.WriteHeader(http.StatusOK)
 		fmt.Fprintf(rw, "[+]%s ok\n", checkName)
@@ -387,6 +451,7 @@ func (r *Reporter) reportManifest(rw http.ResponseWriter, rq *http.Request) {
 		if hc.Error != nil {
 			entry.Error = hc.Error.Error()
 		}
+		entry.Override = hc.Metadata["override"]
 		checks = append(checks, entry)
 	}
 
@@ -397,6 +462,14 @@ func (r *Reporter) reportManifest(rw http.ResponseWriter, rq *http.Request) {
 		Checks:    checks,
 		Timestamp: time.Now(),
 	}
+	r.ovMx.RLock()
+	if len(r.overrides) > 0 {
+		manifest.Overrides = make(map[string]string, len(r.overrides))
+		for probe, reason := range r.overrides {
+			manifest.Overrides[probe] = reason
+		}
+	}
+	r.ovMx.RUnlock()
 
 	rw.Header().Set("Content-Type", "application/json")
 	rw.WriteHeader(http.StatusOK)
@@ -435,6 +508,7 @@ func (r *Reporter) cacheHealthChecks() {
 		Duration         string            `json:"duration,omitempty"`
 		LastCheck        string            `json:"lastCheck,omitempty"`
 		Metadata         map[string]string `json:"metadata,omitempty"`
+		History          *historyJSON      `json:"history,omitempty"`
 	}
 
 	pl := make(map[string]checkJSON)
@@ -461,6 +535,9 @@ func (r *Reporter) cacheHealthChecks() {
 		if !hc.Timestamp.IsZero() {
 			cj.LastCheck = hc.Timestamp.Format(time.RFC3339)
 		}
+		if hc.History != nil {
+			cj.History = toHistoryJSON(hc.History)
+		}
 		pl[k] = cj
 	}
 
@@ -472,3 +549,39 @@ func (r *Reporter) cacheHealthChecks() {
 
 	r.hcCache.write(data)
 }
+
+type executionJSON struct {
+	Status    string `json:"status"`
+	Duration  string `json:"duration,omitempty"`
+	Error     string `json:"error,omitempty"`
+	Timestamp string `json:"timestamp"`
+}
+
+type historyJSON struct {
+	Uptime     float64         `json:"uptime"`
+	Flaps      int             `json:"flaps"`
+	Executions []executionJSON `json:"executions,omitempty"`
+}
+
+// toHistoryJSON converts a check history into its JSON representation.
+func toHistoryJSON(h *health.CheckHistory) *historyJSON {
+	out := historyJSON{
+		Uptime:     h.Uptime,
+		Flaps:      h.Flaps,
+		Executions: make([]executionJSON, 0, len(h.Executions)),
+	}
+	for _, e := range h.Executions {
+		ej := executionJSON{
+			Status:    e.Status.String(),
+			Timestamp: e.Timestamp.Format(time.RFC3339),
+		}
+		if e.Duration > 0 {
+			ej.Duration = e.Duration.String()
+		}
+		if e.Error != nil {
+			ej.Error = e.Error.Error()
+		}
+		out.Executions = append(out.Executions, ej)
+	}
+	return &out
+}
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/schigh/health/v2"
+	"github.com/schigh/health/v2/discovery"
 	"github.com/schigh/health/v2/reporter/httpserver"
 )
 
@@ -419,6 +420,14 @@ type checkJSON struct {
 	Error            string            `json:"error,omitempty"`
 	ErrorSince       string            `json:"errorSince,omitempty"`
 	Metadata         map[string]string `json:"metadata,omitempty"`
+	History          *struct {
+		Uptime     float64 `json:"uptime"`
+		Flaps      int     `json:"flaps"`
+		Executions []struct {
+			Status string `json:"status"`
+			Error  string `json:"error,omitempty"`
+		} `json:"executions"`
+	} `json:"history,omitempty"`
 }
 
 func fetchHealth(t *testing.T, client *http.Client, pathSuffix string) (int, map[string]checkJSON) {
@@ -615,3 +624,237 @@ func TestVerbose(t *testing.T) {
 		}
 	}
 }
+
+func TestRemoveHealthChecks(t *testing.T) {
+	reporter := httpserver.NewReporter(httpserver.Config{
+		Addr:           "0.0.0.0",
+		Port:           8581,
+		LivenessRoute:  "/livez",
+		ReadinessRoute: "/readyz",
+		StartupRoute:   "/healthz",
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(func() {
+		defer cancel()
+		reporter.Stop(ctx)
+	})
+
+	if err := reporter.Run(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	reporter.SetLiveness(ctx, true)
+	reporter.UpdateHealthChecks(ctx, map[string]*health.CheckResult{
+		"postgres": {Name: "postgres", Status: health.StatusHealthy},
+		"redis":    {Name: "redis", Status: health.StatusHealthy},
+	})
+	reporter.RemoveHealthChecks(ctx, []string{"redis"})
+
+	client := http.Client{Timeout: time.Second}
+
+	// removed check is gone from the JSON body
+	{
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://0.0.0.0:8581/livez", http.NoBody)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		var checks map[string]checkJSON
+		if err := json.NewDecoder(resp.Body).Decode(&checks); err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := checks["postgres"]; !ok {
+			t.Error("expected postgres to remain after removal of redis")
+		}
+		if _, ok := checks["redis"]; ok {
+			t.Error("expected redis to be removed from the JSON body")
+		}
+	}
+
+	// removed check is no longer individually addressable
+	{
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://0.0.0.0:8581/livez/redis", http.NoBody)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusNotFound {
+			t.Errorf("/livez/redis: expected 404 after removal, got %d", resp.StatusCode)
+		}
+	}
+}
+
+func TestHistory(t *testing.T) {
+	reporter := httpserver.NewReporter(httpserver.Config{
+		Addr:           "0.0.0.0",
+		Port:           8582,
+		LivenessRoute:  "/livez",
+		ReadinessRoute: "/readyz",
+		StartupRoute:   "/healthz",
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(func() {
+		defer cancel()
+		reporter.Stop(ctx)
+	})
+
+	if err := reporter.Run(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	reporter.SetLiveness(ctx, true)
+	reporter.UpdateHealthChecks(ctx, map[string]*health.CheckResult{
+		"postgres": {
+			Name:   "postgres",
+			Status: health.StatusHealthy,
+			History: &health.CheckHistory{
+				Executions: []health.CheckExecution{
+					{Status: health.StatusUnhealthy, Error: errors.New("refused"), Timestamp: now.Add(-time.Second)},
+					{Status: health.StatusHealthy, Timestamp: now},
+				},
+				Uptime: 0.5,
+				Flaps:  1,
+			},
+		},
+	})
+
+	client := http.Client{Timeout: time.Second}
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://0.0.0.0:8582/livez", http.NoBody)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var checks map[string]checkJSON
+	if err := json.NewDecoder(resp.Body).Decode(&checks); err != nil {
+		t.Fatal(err)
+	}
+
+	h := checks["postgres"].History
+	if h == nil {
+		t.Fatal("expected history in the JSON body")
+	}
+	if h.Uptime != 0.5 || h.Flaps != 1 {
+		t.Errorf("expected uptime 0.5 and 1 flap, got %v and %d", h.Uptime, h.Flaps)
+	}
+	if len(h.Executions) != 2 || h.Executions[0].Status != "unhealthy" || h.Executions[0].Error != "refused" {
+		t.Errorf("unexpected executions: %+v", h.Executions)
+	}
+}
+
+func TestNotReadyProbes(t *testing.T) {
+	reporter := httpserver.NewReporter(httpserver.Config{
+		Addr:           "0.0.0.0",
+		Port:           8583,
+		LivenessRoute:  "/livez",
+		ReadinessRoute: "/readyz",
+		StartupRoute:   "/healthz",
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(func() {
+		defer cancel()
+		reporter.Stop(ctx)
+	})
+
+	if err := reporter.Run(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	client := http.Client{Timeout: time.Second}
+	probe := func() {
+		t.Helper()
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://0.0.0.0:8583/readyz", http.NoBody)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+
+	reporter.SetReadiness(ctx, true)
+	probe()
+	if n := reporter.NotReadyProbes(); n != 0 {
+		t.Fatalf("expected 0 not-ready probes while ready, got %d", n)
+	}
+
+	reporter.SetReadiness(ctx, false)
+	probe()
+	probe()
+	if n := reporter.NotReadyProbes(); n != 2 {
+		t.Fatalf("expected 2 not-ready probes, got %d", n)
+	}
+
+	reporter.SetReadiness(ctx, true)
+	if n := reporter.NotReadyProbes(); n != 0 {
+		t.Fatalf("expected count to reset when ready, got %d", n)
+	}
+}
+
+func TestProbeOverride(t *testing.T) {
+	reporter := httpserver.NewReporter(httpserver.Config{
+		Addr:           "0.0.0.0",
+		Port:           8584,
+		LivenessRoute:  "/livez",
+		ReadinessRoute: "/readyz",
+		StartupRoute:   "/healthz",
+		ServiceName:    "orders",
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(func() {
+		defer cancel()
+		reporter.Stop(ctx)
+	})
+
+	if err := reporter.Run(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	reporter.UpdateHealthChecks(ctx, map[string]*health.CheckResult{
+		"postgres": {Name: "postgres", Status: health.StatusHealthy, Metadata: map[string]string{"override": "failover"}},
+	})
+	reporter.SetProbeOverride(ctx, "readiness", "maintenance")
+
+	client := http.Client{Timeout: time.Second}
+	get := func(path string) *http.Response {
+		t.Helper()
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://0.0.0.0:8584"+path, http.NoBody)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	resp := get("/readyz")
+	resp.Body.Close()
+	if got := resp.Header.Get(httpserver.OverrideHeader); got != "maintenance" {
+		t.Errorf("expected override header 'maintenance', got %q", got)
+	}
+
+	resp = get("/.well-known/health")
+	defer resp.Body.Close()
+	var manifest discovery.Manifest
+	if err := json.NewDecoder(resp.Body).Decode(&manifest); err != nil {
+		t.Fatal(err)
+	}
+	if manifest.Overrides["readiness"] != "maintenance" {
+		t.Errorf("expected readiness override in manifest, got %v", manifest.Overrides)
+	}
+	if len(manifest.Checks) != 1 || manifest.Checks[0].Override != "failover" {
+		t.Errorf("expected check override in manifest, got %+v", manifest.Checks)
+	}
+
+	reporter.SetProbeOverride(ctx, "readiness", "")
+	resp = get("/readyz")
+	resp.Body.Close()
+	if got := resp.Header.Get(httpserver.OverrideHeader); got != "" {
+		t.Errorf("expected no override header after clearing, got %q", got)
+	}
+}
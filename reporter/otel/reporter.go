@@ -15,7 +15,7 @@ import (
 // Reporter implements health.Reporter and emits OpenTelemetry metrics.
 //
 // Metrics emitted:
-//   - health.check.status (gauge, per check): 0=unhealthy, 1=degraded, 2=healthy
+//   - health.check.status (gauge, per check): 0=unhealthy, 1=degraded, 2=healthy or skipped
 //   - health.check.duration (histogram, per check): check execution time in milliseconds
 //   - health.check.executions (counter, per check): total check executions by status
 //   - health.liveness (gauge): 0 or 1
@@ -56,7 +56,7 @@ func NewReporter(cfg Config) (*Reporter, error) {
 	meter := cfg.MeterProvider.Meter("github.com/schigh/health/v2/reporter/otel")
 
 	checkGauge, err := meter.Int64Gauge("health.check.status",
-		metric.WithDescription("Health check status: 0=unhealthy, 1=degraded, 2=healthy"),
+		metric.WithDescription("Health check status: 0=unhealthy, 1=degraded, 2=healthy or skipped"),
 	)
 	if err != nil {
 		return nil, err
@@ -170,6 +170,16 @@ func (r *Reporter) UpdateHealthChecks(ctx context.Context, m map[string]*health.
 	}
 }
 
+// RemoveHealthChecks implements health.CheckRemover.
+func (r *Reporter) RemoveHealthChecks(_ context.Context, names []string) {
+	r.hcMx.Lock()
+	defer r.hcMx.Unlock()
+
+	for _, name := range names {
+		delete(r.hcs, name)
+	}
+}
+
 // recordCheckMetrics emits OTel metrics for a single health check result.
 func (r *Reporter) recordCheckMetrics(ctx context.Context, hc *health.CheckResult) {
 	attrs := []attribute.KeyValue{
@@ -197,7 +207,7 @@ func (r *Reporter) recordCheckMetrics(ctx context.Context, hc *health.CheckResul
 // statusToInt64 converts a health status to its numeric OTel gauge value.
 func statusToInt64(s health.Status) int64 {
 	switch s {
-	case health.StatusHealthy:
+	case health.StatusHealthy, health.StatusSkipped:
 		return 2
 	case health.StatusDegraded:
 		return 1
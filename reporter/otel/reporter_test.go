@@ -110,6 +110,27 @@ func TestReporter_CheckStatusMetric(t *testing.T) {
 	}
 }
 
+func TestReporter_SkippedCheck(t *testing.T) {
+	r, reader := setupReporter(t)
+
+	r.UpdateHealthChecks(context.Background(), map[string]*health.CheckResult{
+		"cache": {Name: "cache", Status: health.StatusSkipped},
+	})
+
+	rm := collectMetrics(t, reader)
+	status := findMetric(rm, "health.check.status")
+	if status == nil {
+		t.Fatal("health.check.status metric not found")
+	}
+	gauge, ok := status.Data.(metricdata.Gauge[int64])
+	if !ok || len(gauge.DataPoints) != 1 {
+		t.Fatalf("expected one int64 gauge data point, got %+v", status.Data)
+	}
+	if v := gauge.DataPoints[0].Value; v != 2 {
+		t.Fatalf("expected skipped check to report as healthy (2), got %d", v)
+	}
+}
+
 func TestReporter_NotRunning(t *testing.T) {
 	r, reader := setupReporter(t)
 	r.Stop(context.Background())
@@ -15,7 +15,7 @@ import (
 // Reporter implements health.Reporter and exposes Prometheus metrics.
 //
 // Metrics:
-//   - health_check_status (gauge, labels: check, group, component_type): 0=unhealthy, 1=degraded, 2=healthy
+//   - health_check_status (gauge, labels: check, group, component_type): 0=unhealthy, 1=degraded, 2=healthy or skipped
 //   - health_check_duration_milliseconds (gauge, labels: check, group, component_type): last check duration
 //   - health_check_executions_total (counter, labels: check, group, component_type, status): total executions
 //   - health_liveness (gauge): 0 or 1
@@ -61,7 +61,7 @@ func NewReporter(cfg Config) *Reporter {
 
 	checkGauge := prometheus.NewGaugeVec(prometheus.GaugeOpts{
 		Namespace: ns, Name: "health_check_status",
-		Help: "Health check status: 0=unhealthy, 1=degraded, 2=healthy",
+		Help: "Health check status: 0=unhealthy, 1=degraded, 2=healthy or skipped",
 	}, labels)
 
 	checkDur := prometheus.NewGaugeVec(prometheus.GaugeOpts{
@@ -173,7 +173,7 @@ func (r *Reporter) UpdateHealthChecks(_ context.Context, m map[string]*health.Ch
 
 		var statusVal float64
 		switch hc.Status {
-		case health.StatusHealthy:
+		case health.StatusHealthy, health.StatusSkipped:
 			statusVal = 2
 		case health.StatusDegraded:
 			statusVal = 1
@@ -196,3 +196,19 @@ func (r *Reporter) UpdateHealthChecks(_ context.Context, m map[string]*health.Ch
 		r.checkCount.With(statusLabels).Inc()
 	}
 }
+
+// RemoveHealthChecks implements health.CheckRemover. The status and duration
+// series of removed checks are deleted so they stop being exported.
+func (r *Reporter) RemoveHealthChecks(_ context.Context, names []string) {
+	r.hcMx.Lock()
+	for _, name := range names {
+		delete(r.hcs, name)
+	}
+	r.hcMx.Unlock()
+
+	for _, name := range names {
+		labels := prometheus.Labels{"check": name}
+		r.checkGauge.DeletePartialMatch(labels)
+		r.checkDur.DeletePartialMatch(labels)
+	}
+}
@@ -97,6 +97,28 @@ func TestReporter_UnhealthyCheck(t *testing.T) {
 	}
 }
 
+func TestReporter_SkippedCheck(t *testing.T) {
+	r := healthprom.NewReporter(healthprom.Config{})
+	r.Run(context.Background())
+	r.UpdateHealthChecks(context.Background(), map[string]*health.CheckResult{
+		"cache": {Name: "cache", Status: health.StatusSkipped},
+	})
+
+	srv := httptest.NewServer(r.Handler())
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if !strings.Contains(string(body), `health_check_status{check="cache",component_type="",group=""} 2`) {
+		t.Errorf("expected skipped check to report as healthy, got:\n%s", body)
+	}
+}
+
 func TestReporter_NotRunning(t *testing.T) {
 	r := healthprom.NewReporter(healthprom.Config{})
 	// don't call Run — reporter not running
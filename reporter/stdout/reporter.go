@@ -68,6 +68,14 @@ func (r *Reporter) UpdateHealthChecks(_ context.Context, m map[string]*health.Ch
 	r.reportHealthChecks(w)
 }
 
+// RemoveHealthChecks implements health.CheckRemover.
+func (r *Reporter) RemoveHealthChecks(_ context.Context, names []string) {
+	for _, name := range names {
+		r.healthChecks.Delete(name)
+	}
+	r.reportHealthChecks(w)
+}
+
 func (r *Reporter) reportLiveness(out io.Writer) {
 	live := no
 	if atomic.LoadUint32(&r.live) == 1 {
@@ -21,6 +21,7 @@ type Reporter struct {
 	startup        uint32       // flag to determine if the reporter shows startup
 	startupToggles uint32       // number of times startup changed state
 	hcUpdates      uint32       // number of times health checks have been updated
+	hcRemovals     uint32       // number of times health checks have been removed
 
 	liveUp     uint32                          // number of times liveness toggled true
 	liveDown   uint32                          // number of times liveness toggled false
@@ -29,6 +30,7 @@ type Reporter struct {
 	startupUp  uint32                          // number of times startup toggled true
 	startupDown uint32                         // number of times startup toggled false
 	hc         map[string]*health.CheckResult  // internal map of health checks
+	overrides  map[string]string               // probe override reasons
 }
 
 // Report is a snapshot of this reporter's state.
@@ -48,7 +50,9 @@ type Report struct {
 	NumStartupSetTrue        uint32                         `json:"-"`
 	NumStartupSetFalse       uint32                         `json:"-"`
 	NumHealthCheckUpdates    uint32                         `json:"-"`
+	NumHealthCheckRemovals   uint32                         `json:"-"`
 	HealthChecks             map[string]*health.CheckResult `json:"-"`
+	ProbeOverrides           map[string]string              `json:"-"`
 }
 
 func (r Report) MarshalJSON() ([]byte, error) {
@@ -68,7 +72,9 @@ func (r Report) MarshalJSON() ([]byte, error) {
 		NumStartupSetTrue        uint32            `json:"numStartupSetTrue"`
 		NumStartupSetFalse       uint32            `json:"numStartupSetFalse"`
 		NumHealthCheckUpdates    uint32            `json:"numHealthCheckUpdates"`
+		NumHealthCheckRemovals   uint32            `json:"numHealthCheckRemovals"`
 		HealthChecks             map[string]any    `json:"healthChecks"`
+		ProbeOverrides           map[string]string `json:"probeOverrides,omitempty"`
 	}
 	out := alias{
 		IsRunning:                r.IsRunning,
@@ -78,6 +84,7 @@ func (r Report) MarshalJSON() ([]byte, error) {
 		IsReady:                  r.IsReady,
 		NumReadinessStateChanges: r.NumReadinessStateChanges,
 		NumHealthCheckUpdates:    r.NumHealthCheckUpdates,
+		NumHealthCheckRemovals:   r.NumHealthCheckRemovals,
 		NumLivenessSetTrue:       r.NumLivenessSetTrue,
 		NumLivenessSetFalse:      r.NumLivenessSetFalse,
 		NumReadinessSetTrue:      r.NumReadinessSetTrue,
@@ -86,6 +93,7 @@ func (r Report) MarshalJSON() ([]byte, error) {
 		NumStartupStateChanges:   r.NumStartupStateChanges,
 		NumStartupSetTrue:        r.NumStartupSetTrue,
 		NumStartupSetFalse:       r.NumStartupSetFalse,
+		ProbeOverrides:           r.ProbeOverrides,
 	}
 
 	hcs := make(map[string]any)
@@ -116,6 +124,10 @@ func (t *Reporter) Report() Report {
 	for k := range t.hc {
 		healthChecks[k] = t.hc[k]
 	}
+	overrides := make(map[string]string)
+	for k := range t.overrides {
+		overrides[k] = t.overrides[k]
+	}
 
 	return Report{
 		IsRunning:                atomic.LoadUint32(&t.running) == 1,
@@ -125,6 +137,7 @@ func (t *Reporter) Report() Report {
 		IsReady:                  atomic.LoadUint32(&t.ready) == 1,
 		NumReadinessStateChanges: atomic.LoadUint32(&t.readyToggles),
 		NumHealthCheckUpdates:    atomic.LoadUint32(&t.hcUpdates),
+		NumHealthCheckRemovals:   atomic.LoadUint32(&t.hcRemovals),
 		NumLivenessSetTrue:       atomic.LoadUint32(&t.liveUp),
 		NumLivenessSetFalse:      atomic.LoadUint32(&t.liveDown),
 		NumReadinessSetTrue:      atomic.LoadUint32(&t.readyUp),
@@ -134,6 +147,7 @@ func (t *Reporter) Report() Report {
 		NumStartupSetTrue:        atomic.LoadUint32(&t.startupUp),
 		NumStartupSetFalse:       atomic.LoadUint32(&t.startupDown),
 		HealthChecks:             healthChecks,
+		ProbeOverrides:           overrides,
 	}
 }
 
@@ -190,3 +204,29 @@ func (t *Reporter) UpdateHealthChecks(_ context.Context, m map[string]*health.Ch
 		t.hc[k] = m[k]
 	}
 }
+
+// RemoveHealthChecks implements health.CheckRemover.
+func (t *Reporter) RemoveHealthChecks(_ context.Context, names []string) {
+	defer atomic.AddUint32(&t.hcRemovals, 1)
+	defer t.hcMx.Unlock()
+	t.hcMx.Lock()
+
+	for _, name := range names {
+		delete(t.hc, name)
+	}
+}
+
+// SetProbeOverride implements health.OverrideReporter.
+func (t *Reporter) SetProbeOverride(_ context.Context, probe, reason string) {
+	defer t.hcMx.Unlock()
+	t.hcMx.Lock()
+
+	if reason == "" {
+		delete(t.overrides, probe)
+		return
+	}
+	if t.overrides == nil {
+		t.overrides = make(map[string]string)
+	}
+	t.overrides[probe] = reason
+}